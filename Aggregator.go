@@ -0,0 +1,223 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "bufio"
+    "fmt"
+    "io"
+    "strings"
+)
+
+//
+//	        Aggregator.go				Streaming Aggregation of Decimals
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Aggregator Type
+//			01  - Aggregator			Running count, sum, minimum, maximum, mean and variance
+//			02  - NewAggregator			Creates an Aggregator with a given decimal precision
+//		02 Aggregator Input
+//			01  - Aggregator.Add			Consumes one decimal
+//			02  - Aggregator.AddFromReader		Consumes one decimal per line from a reader
+//		03 Aggregator Results
+//			01  - Aggregator.Count			Number of consumed decimals
+//			02  - Aggregator.Sum			Exact sum of the consumed decimals
+//			03  - Aggregator.Min			Smallest consumed decimal
+//			04  - Aggregator.Max			Largest consumed decimal
+//			05  - Aggregator.Mean			Arithmetic mean
+//			06  - Aggregator.Variance		Population variance
+//			07  - Aggregator.SampleVariance		Sample variance
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Aggregator Type
+//		Unlike SumDL, which needs the whole slice in memory, the Aggregator consumes the
+//		decimals one at a time. Count, Sum, Min and Max are exact. The mean is Sum / Count,
+//		the variance uses Welford's online algorithm, both being truncated to the Aggregator
+//		decimal precision, the running values keeping SeriesGuardDigits extra decimals.
+//
+// ================================================
+//
+// # Type 01.01 - Aggregator
+//
+// Aggregator maintains the statistics of the decimals consumed so far.
+// The zero value is an empty Aggregator whose Mean and variances have no decimals.
+type Aggregator struct {
+    DecimalPrecision uint32
+    count            int64
+    sumDecimals      uint32
+    sum              *p.Decimal
+    min              *p.Decimal
+    max              *p.Decimal
+    mean             *p.Decimal
+    m2               *p.Decimal
+}
+
+// ================================================
+//
+// # Function 01.02 - NewAggregator
+//
+// NewAggregator creates an empty Aggregator, whose Mean and variances have DecimalPrecision decimals.
+func NewAggregator(DecimalPrecision uint32) *Aggregator {
+    return &Aggregator{
+        DecimalPrecision: DecimalPrecision,
+        sum:              p.NFI(0),
+        mean:             p.NFI(0),
+        m2:               p.NFI(0),
+    }
+}
+
+// ================================================================================================
+//
+//	02 Aggregator Input
+//
+// ================================================
+//
+// # Function 02.01 - Aggregator.Add
+//
+// Add consumes one decimal.
+func (a *Aggregator) Add(Number *p.Decimal) {
+    Working := a.DecimalPrecision + SeriesGuardDigits
+    if a.sum == nil {
+        a.sum, a.mean, a.m2 = p.NFI(0), p.NFI(0), p.NFI(0)
+    }
+    if Number.Exponent < 0 && uint32(0-Number.Exponent) > a.sumDecimals {
+        a.sumDecimals = uint32(0 - Number.Exponent)
+    }
+    a.count++
+    a.sum = ADD(a.sumDecimals, a.sum, Number)
+    if a.min == nil || DecimalLessThan(Number, a.min) == true {
+        a.min = new(p.Decimal).Set(Number)
+    }
+    if a.max == nil || DecimalGreaterThan(Number, a.max) == true {
+        a.max = new(p.Decimal).Set(Number)
+    }
+    
+    //Welford: Mean += (x - Mean) / n, M2 += (x - OldMean) * (x - NewMean)
+    Delta := SUB(Working, Number, a.mean)
+    a.mean = ADD(Working, a.mean, DIV(Working, Delta, p.NFI(a.count)))
+    a.m2 = ADD(Working, a.m2, MUL(Working, Delta, SUB(Working, Number, a.mean)))
+}
+
+// ================================================
+//
+// # Function 02.02 - Aggregator.AddFromReader
+//
+// AddFromReader consumes one decimal per line from the Reader, without keeping them in memory.
+// Blank lines are skipped, values are parsed with ParsePlainDecimal. It returns the number of
+// decimals consumed; on a parsing error the decimals before the offending line remain consumed.
+func (a *Aggregator) AddFromReader(Reader io.Reader) (int64, error) {
+    var Consumed int64
+    Scanner := bufio.NewScanner(Reader)
+    Line := 0
+    for Scanner.Scan() {
+        Line++
+        Text := strings.TrimSpace(Scanner.Text())
+        if Text == "" {
+            continue
+        }
+        Number, err := ParsePlainDecimal(Text, MaxMathPrecision)
+        if err != nil {
+            return Consumed, fmt.Errorf("Aggregator.AddFromReader: line %d: %w", Line, err)
+        }
+        a.Add(Number)
+        Consumed++
+    }
+    if err := Scanner.Err(); err != nil {
+        return Consumed, fmt.Errorf("Aggregator.AddFromReader: %w", err)
+    }
+    return Consumed, nil
+}
+
+// ================================================================================================
+//
+//	03 Aggregator Results
+//
+// ================================================
+//
+// # Function 03.01 - Aggregator.Count
+//
+// Count returns the number of consumed decimals.
+func (a *Aggregator) Count() int64 {
+    return a.count
+}
+
+// ================================================
+//
+// # Function 03.02 - Aggregator.Sum
+//
+// Sum returns the exact sum of the consumed decimals, zero when none was consumed.
+func (a *Aggregator) Sum() *p.Decimal {
+    if a.sum == nil {
+        return p.NFI(0)
+    }
+    return new(p.Decimal).Set(a.sum)
+}
+
+// ================================================
+//
+// # Function 03.03 - Aggregator.Min
+//
+// Min returns the smallest consumed decimal, or an error when none was consumed.
+func (a *Aggregator) Min() (*p.Decimal, error) {
+    if a.count == 0 {
+        return nil, fmt.Errorf("Aggregator.Min: no decimals consumed")
+    }
+    return new(p.Decimal).Set(a.min), nil
+}
+
+// ================================================
+//
+// # Function 03.04 - Aggregator.Max
+//
+// Max returns the largest consumed decimal, or an error when none was consumed.
+func (a *Aggregator) Max() (*p.Decimal, error) {
+    if a.count == 0 {
+        return nil, fmt.Errorf("Aggregator.Max: no decimals consumed")
+    }
+    return new(p.Decimal).Set(a.max), nil
+}
+
+// ================================================
+//
+// # Function 03.05 - Aggregator.Mean
+//
+// Mean returns Sum / Count truncated to DecimalPrecision, or an error when no decimal was consumed.
+func (a *Aggregator) Mean() (*p.Decimal, error) {
+    if a.count == 0 {
+        return nil, fmt.Errorf("Aggregator.Mean: no decimals consumed")
+    }
+    return DIV(a.DecimalPrecision, a.sum, p.NFI(a.count)), nil
+}
+
+// ================================================
+//
+// # Function 03.06 - Aggregator.Variance
+//
+// Variance returns the population variance M2 / Count truncated to DecimalPrecision,
+// or an error when no decimal was consumed.
+func (a *Aggregator) Variance() (*p.Decimal, error) {
+    if a.count == 0 {
+        return nil, fmt.Errorf("Aggregator.Variance: no decimals consumed")
+    }
+    return DIV(a.DecimalPrecision, a.m2, p.NFI(a.count)), nil
+}
+
+// ================================================
+//
+// # Function 03.07 - Aggregator.SampleVariance
+//
+// SampleVariance returns the sample variance M2 / (Count - 1) truncated to DecimalPrecision,
+// or an error when fewer than two decimals were consumed.
+func (a *Aggregator) SampleVariance() (*p.Decimal, error) {
+    if a.count < 2 {
+        return nil, fmt.Errorf("Aggregator.SampleVariance: at least two decimals are needed, %d consumed", a.count)
+    }
+    return DIV(a.DecimalPrecision, a.m2, p.NFI(a.count-1)), nil
+}
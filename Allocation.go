@@ -0,0 +1,184 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "fmt"
+    "sort"
+)
+
+//
+//	        Allocation.go				Lossless Proportional Allocation of CryptoPlasm Amounts
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Allocation Types
+//			01  - TieBreak				Order of recipients having equal remainders
+//			02  - AllocationReport			How the residual AttoPlasms were distributed
+//		02 Allocation Functions
+//			01  - Allocate				Splits an amount by weights, the shares summing exactly to the amount
+//			02  - AllocateWith			Allocate using a custom TieBreak
+//			03  - SplitEvenly			Splits an amount into n shares differing by at most one AttoPlasm
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Allocation Types
+//		Splitting an amount with DIVxc followed by TruncToCurrency leaves dust nobody receives.
+//		The allocation functions work on AttoPlasms: every recipient first receives the
+//		truncated share, then the residual AttoPlasms are handed out one by one
+//		to the recipients having the largest remainders (Largest Remainder Method).
+//
+// ================================================
+//
+// # Type 01.01 - TieBreak
+//
+// TieBreak sets which recipient receives a residual AttoPlasm first,
+// when two recipients have equal remainders.
+type TieBreak int
+
+const (
+    TieBreakFirst         TieBreak = iota // Lower index first
+    TieBreakLast                          // Higher index first
+    TieBreakLargestWeight                 // Larger weight first, then lower index
+)
+
+// ================================================
+//
+// # Type 01.02 - AllocationReport
+//
+// AllocationReport describes how the residual was distributed.
+// Residual is the number of AttoPlasms left after the truncated shares were paid,
+// Recipients holds the indices that received one extra AttoPlasm, in the order they received it.
+type AllocationReport struct {
+    Residual   *p.Decimal
+    Recipients []int
+}
+
+// ================================================================================================
+//
+//	02 Allocation Functions
+//
+// ================================================
+//
+// # Function 02.01 - Allocate
+//
+// Allocate splits the Total (truncated to CurrencyPrecision) proportionally to the Weights.
+// The returned shares have CurrencyPrecision decimals and add up exactly to the Total.
+// Equal remainders are broken in favour of the lower index (TieBreakFirst).
+// Weights must not be negative, and at least one must be greater than zero.
+func Allocate(Total *p.Decimal, Weights []*p.Decimal) ([]*p.Decimal, AllocationReport, error) {
+    Shares, Report, err := AllocateWith(Total, Weights, TieBreakFirst)
+    if err != nil {
+        return nil, AllocationReport{}, fmt.Errorf("Allocate: %w", err)
+    }
+    return Shares, Report, nil
+}
+
+// ================================================
+//
+// # Function 02.02 - AllocateWith
+//
+// AllocateWith splits the Total like Allocate does, breaking equal remainders using the Tie policy.
+func AllocateWith(Total *p.Decimal, Weights []*p.Decimal, Tie TieBreak) ([]*p.Decimal, AllocationReport, error) {
+    var (
+        WeightDecimals uint32
+        Zero           = p.NFI(0)
+    )
+    if len(Weights) == 0 {
+        return nil, AllocationReport{}, fmt.Errorf("AllocateWith: no weights given")
+    }
+    if Total.Form != p.Finite {
+        return nil, AllocationReport{}, fmt.Errorf("AllocateWith: total %s is not finite", Total.String())
+    }
+    if DecimalLessThan(Total, Zero) == true {
+        return nil, AllocationReport{}, fmt.Errorf("AllocateWith: negative total %s", Total.String())
+    }
+    for i, Weight := range Weights {
+        if Weight.Form != p.Finite {
+            return nil, AllocationReport{}, fmt.Errorf("AllocateWith: weight %d is not finite: %s", i, Weight.String())
+        }
+        if DecimalLessThan(Weight, Zero) == true {
+            return nil, AllocationReport{}, fmt.Errorf("AllocateWith: weight %d is negative: %s", i, Weight.String())
+        }
+        if Weight.Exponent < 0 && uint32(0-Weight.Exponent) > WeightDecimals {
+            WeightDecimals = uint32(0 - Weight.Exponent)
+        }
+    }
+    WeightSum := SUM(WeightDecimals, Weights[0], Weights[1:]...)
+    if WeightSum.IsZero() == true {
+        return nil, AllocationReport{}, fmt.Errorf("AllocateWith: the weights sum up to zero")
+    }
+    
+    //Truncated shares and their exact remainders, all in AttoPlasms.
+    //Share = (TotalAU * Weight) // WeightSum, Remainder = (TotalAU * Weight) % WeightSum
+    //The Total is normalized first, Convert2AU not handling positive exponents.
+    TotalAU := MUL(0, Convert2AU(MUL(CurrencyPrecision, Total, p.NFI(1))), p.NFI(1))
+    Shares := make([]*p.Decimal, len(Weights))
+    Remainders := make([]*p.Decimal, len(Weights))
+    Paid := p.NFI(0)
+    for i, Weight := range Weights {
+        Product := MUL(WeightDecimals, TotalAU, Weight)
+        Shares[i] = DIV(0, Product, WeightSum)
+        Remainders[i] = SUB(WeightDecimals, Product, MUL(WeightDecimals, Shares[i], WeightSum))
+        Paid = ADD(0, Paid, Shares[i])
+    }
+    Report := AllocationReport{Residual: SUB(0, TotalAU, Paid)}
+    
+    //The Residual is lower than the number of recipients, each one receives at most one AttoPlasm.
+    Order := make([]int, len(Weights))
+    for i := range Order {
+        Order[i] = i
+    }
+    sort.SliceStable(Order, func(a, b int) bool {
+        i, j := Order[a], Order[b]
+        if Comparison := Remainders[i].Cmp(Remainders[j]); Comparison != 0 {
+            return Comparison > 0
+        }
+        switch Tie {
+        case TieBreakLast:
+            return i > j
+        case TieBreakLargestWeight:
+            if Comparison := Weights[i].Cmp(Weights[j]); Comparison != 0 {
+                return Comparison > 0
+            }
+        }
+        return i < j
+    })
+    One := p.NFI(1)
+    for k := int64(0); k < p.INT64(Report.Residual); k++ {
+        Shares[Order[k]] = ADD(0, Shares[Order[k]], One)
+        Report.Recipients = append(Report.Recipients, Order[k])
+    }
+    
+    for i := range Shares {
+        Shares[i] = DIV(CurrencyPrecision, Shares[i], AUs)
+    }
+    return Shares, Report, nil
+}
+
+// ================================================
+//
+// # Function 02.03 - SplitEvenly
+//
+// SplitEvenly splits the Total (truncated to CurrencyPrecision) into N shares,
+// which add up exactly to the Total. The shares differ by at most one AttoPlasm,
+// the first recipients receiving the residual AttoPlasms.
+func SplitEvenly(Total *p.Decimal, N int) ([]*p.Decimal, AllocationReport, error) {
+    if N <= 0 {
+        return nil, AllocationReport{}, fmt.Errorf("SplitEvenly: the number of recipients must be positive, got %d", N)
+    }
+    Weights := make([]*p.Decimal, N)
+    for i := range Weights {
+        Weights[i] = p.NFI(1)
+    }
+    Shares, Report, err := AllocateWith(Total, Weights, TieBreakFirst)
+    if err != nil {
+        return nil, AllocationReport{}, fmt.Errorf("SplitEvenly: %w", err)
+    }
+    return Shares, Report, nil
+}
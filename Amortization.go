@@ -0,0 +1,213 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "encoding/csv"
+    "fmt"
+    "io"
+    "strconv"
+    "strings"
+)
+
+//
+//	        Amortization.go				Loan Amortization Schedule Generator
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Amortization Types
+//			01  - AmortizationMethod		How the principal is paid back
+//			02  - AmortizationRow			One payment of the schedule
+//		02 Amortization Functions
+//			01  - Amortize				Generates a monthly amortization schedule
+//			02  - AmortizeWith			Generates an amortization schedule for any number of payments per year
+//			03  - AnnuityPayment			Returns the constant payment of an annuity loan
+//		03 Amortization Output
+//			01  - AmortizationRow.Record		Returns the row as a slice of strings
+//			02  - AmortizationLines			Returns the schedule as lines, to be written with WriteList
+//			03  - WriteAmortizationCSV		Writes the schedule as CSV
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Amortization Types
+//		All amounts are truncated to CurrencyPrecision, the interest of each period being
+//		computed on the remaining balance. The last payment pays the whole remaining balance,
+//		so that it reaches exactly zero.
+//
+// ================================================
+//
+// # Type 01.01 - AmortizationMethod
+//
+// AmortizationMethod sets how the principal is paid back.
+type AmortizationMethod int
+
+const (
+    AmortizeAnnuity AmortizationMethod = iota // Constant payments, the principal part growing over time
+    AmortizeLinear                            // Constant principal part, decreasing payments
+    AmortizeBalloon                           // Interest only payments, the principal being paid with the last one
+)
+
+// DefaultPaymentsPerYear is the number of payments per year used by Amortize.
+const DefaultPaymentsPerYear = int64(12)
+
+// AmortizationHeader names the columns of AmortizationRow.Record
+var AmortizationHeader = []string{"Period", "Payment", "Interest", "Principal", "Balance"}
+
+// ================================================
+//
+// # Type 01.02 - AmortizationRow
+//
+// AmortizationRow is one payment of the schedule: the Payment is made of
+// Interest and Principal, Balance being the principal remaining after it.
+type AmortizationRow struct {
+    Period    int64
+    Payment   *p.Decimal
+    Interest  *p.Decimal
+    Principal *p.Decimal
+    Balance   *p.Decimal
+}
+
+// ================================================================================================
+//
+//	02 Amortization Functions
+//
+// ================================================
+//
+// # Function 02.01 - Amortize
+//
+// Amortize generates the schedule of a loan of Principal at the nominal AnnualRate
+// (0.06 for 6%), paid back in Periods monthly payments using the given Method.
+func Amortize(Principal, AnnualRate *p.Decimal, Periods int64, Method AmortizationMethod) ([]AmortizationRow, error) {
+    return AmortizeWith(Principal, AnnualRate, Periods, Method, DefaultPaymentsPerYear)
+}
+
+// ================================================
+//
+// # Function 02.02 - AmortizeWith
+//
+// AmortizeWith generates the schedule like Amortize, the periodic rate being AnnualRate / PaymentsPerYear.
+func AmortizeWith(Principal, AnnualRate *p.Decimal, Periods int64, Method AmortizationMethod, PaymentsPerYear int64) ([]AmortizationRow, error) {
+    var (
+        Zero     = p.NFI(0)
+        Payment  *p.Decimal
+        Schedule []AmortizationRow
+    )
+    if Periods <= 0 {
+        return nil, fmt.Errorf("Amortize: the number of periods must be positive, got %d", Periods)
+    }
+    if PaymentsPerYear <= 0 {
+        return nil, fmt.Errorf("Amortize: the number of payments per year must be positive, got %d", PaymentsPerYear)
+    }
+    if DecimalLessThan(Principal, Zero) == true || DecimalLessThan(AnnualRate, Zero) == true {
+        return nil, fmt.Errorf("Amortize: principal %s and rate %s must not be negative", Principal.String(), AnnualRate.String())
+    }
+    
+    Balance := TruncToCurrency(MUL(CurrencyPrecision, Principal, p.NFI(1)))
+    Rate := DIV(CurrencyPrecision+InterestGuardDigits, AnnualRate, p.NFI(PaymentsPerYear))
+    switch Method {
+    case AmortizeAnnuity:
+        Payment = AnnuityPayment(Balance, Rate, Periods)
+    case AmortizeLinear:
+        Payment = DIV(CurrencyPrecision, Balance, p.NFI(Periods))
+    case AmortizeBalloon:
+        Payment = Zero
+    default:
+        return nil, fmt.Errorf("Amortize: unknown amortization method %d", Method)
+    }
+    
+    for Period := int64(1); Period <= Periods; Period++ {
+        Row := AmortizationRow{Period: Period, Interest: MUL(CurrencyPrecision, Balance, Rate)}
+        switch {
+        case Period == Periods:
+            Row.Principal = Balance
+        case Method == AmortizeAnnuity:
+            Row.Principal = MinDecimal(SUB(CurrencyPrecision, Payment, Row.Interest), Balance)
+        default:
+            Row.Principal = MinDecimal(Payment, Balance)
+        }
+        if DecimalLessThan(Row.Principal, Zero) == true {
+            Row.Principal = Zero
+        }
+        Row.Payment = ADD(CurrencyPrecision, Row.Interest, Row.Principal)
+        Balance = SUB(CurrencyPrecision, Balance, Row.Principal)
+        Row.Balance = Balance
+        Schedule = append(Schedule, Row)
+    }
+    return Schedule, nil
+}
+
+// ================================================
+//
+// # Function 02.03 - AnnuityPayment
+//
+// AnnuityPayment returns the constant payment paying back the Principal in Periods payments
+// at the periodic Rate: Principal * Rate / (1 - (1 + Rate) ** -Periods),
+// or Principal / Periods for a zero Rate. The payment is truncated to CurrencyPrecision.
+func AnnuityPayment(Principal, Rate *p.Decimal, Periods int64) *p.Decimal {
+    Decimals := CurrencyPrecision + InterestGuardDigits
+    if Rate.IsZero() == true {
+        return DIV(CurrencyPrecision, Principal, p.NFI(Periods))
+    }
+    Growth := powInt(Decimals, ADD(Decimals, p.NFI(1), Rate), Periods)
+    //Principal * Rate * Growth / (Growth - 1), Growth being (1 + Rate) ** Periods
+    Numerator := MUL(Decimals, MUL(Decimals, Principal, Rate), Growth)
+    return DIV(CurrencyPrecision, Numerator, SUB(Decimals, Growth, p.NFI(1)))
+}
+
+// ================================================================================================
+//
+//	03 Amortization Output
+//
+// ================================================
+//
+// # Function 03.01 - AmortizationRow.Record
+//
+// Record returns the row as strings, in the order of AmortizationHeader,
+// the amounts being written with CurrencyPrecision decimals and no group separators.
+func (r AmortizationRow) Record() []string {
+    Plain := FormatSchema{Name: "Plain", DecimalSeparator: "."}
+    return []string{strconv.FormatInt(r.Period, 10),
+        FormatCurrency(r.Payment, Plain), FormatCurrency(r.Interest, Plain),
+        FormatCurrency(r.Principal, Plain), FormatCurrency(r.Balance, Plain)}
+}
+
+// ================================================
+//
+// # Function 03.02 - AmortizationLines
+//
+// AmortizationLines returns the header and the rows of the schedule as lines,
+// the values being separated by Separator. The lines can be written using WriteList.
+func AmortizationLines(Schedule []AmortizationRow, Separator string) []string {
+    Lines := []string{strings.Join(AmortizationHeader, Separator)}
+    for _, Row := range Schedule {
+        Lines = append(Lines, strings.Join(Row.Record(), Separator))
+    }
+    return Lines
+}
+
+// ================================================
+//
+// # Function 03.03 - WriteAmortizationCSV
+//
+// WriteAmortizationCSV writes the header and the rows of the schedule as CSV records.
+func WriteAmortizationCSV(Writer io.Writer, Schedule []AmortizationRow) error {
+    CSV := csv.NewWriter(Writer)
+    if err := CSV.Write(AmortizationHeader); err != nil {
+        return fmt.Errorf("WriteAmortizationCSV: %w", err)
+    }
+    for _, Row := range Schedule {
+        if err := CSV.Write(Row.Record()); err != nil {
+            return fmt.Errorf("WriteAmortizationCSV: %w", err)
+        }
+    }
+    CSV.Flush()
+    if err := CSV.Error(); err != nil {
+        return fmt.Errorf("WriteAmortizationCSV: %w", err)
+    }
+    return nil
+}
@@ -0,0 +1,229 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "errors"
+    "fmt"
+)
+
+//
+//	        Amount.go				CryptoPlasm Amount Type based on AttoPlasm Integer Arithmetic
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Amount Type
+//			01  - Amount				Non-negative integer number of AttoPlasms
+//			02  - AmountFromKoson			Creates an Amount from a Koson decimal, rejecting sub-atomic fractions
+//			03  - AmountFromAttoPlasms		Creates an Amount from an integer number of AttoPlasms
+//			04  - ZeroAmount			Returns an Amount of zero AttoPlasms
+//		02 Amount Arithmetic
+//			01  - Amount.Add			a + b
+//			02  - Amount.Sub			a - b, with negative balance check
+//			03  - Amount.MulRatio			a * Numerator / Denominator, truncated to whole AttoPlasms
+//			04  - Amount.Div			a // n and a % n, n being a positive integer
+//		03 Amount Accessors
+//			01  - Amount.AttoPlasms			Returns the number of AttoPlasms as an integer decimal
+//			02  - Amount.Koson			Returns the Koson value as a decimal with CurrencyPrecision decimals
+//			03  - Amount.Cmp			Compares two Amounts
+//			04  - Amount.IsZero			Returns true for a zero Amount
+//			05  - Amount.String			Prints the Amount using KosonicDecimalConversion
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Amount Type
+//		An Amount stores an integer number of AttoPlasms (AuPerUnit AttoPlasms make one Koson),
+//		so sub-atomic fractions can never leak into balances. Amounts are never negative,
+//		and are limited to AmountMaxDigits AttoPlasm digits.
+//
+// ================================================
+
+// AmountMaxDigits is the maximum number of digits of an Amount expressed in AttoPlasms
+const AmountMaxDigits = 36
+
+// Errors returned by the Amount functions
+var (
+    ErrAmountOverflow   = errors.New("amount overflows the maximum number of AttoPlasm digits")
+    ErrNegativeBalance  = errors.New("amount would become negative")
+    ErrSubAtomicAmount  = errors.New("amount has a fraction smaller than one AttoPlasm")
+    ErrAmountDivByZero  = errors.New("amount division by zero")
+    ErrAmountNotInteger = errors.New("divisor must be a positive integer")
+)
+
+// ================================================
+//
+// # Type 01.01 - Amount
+//
+// Amount is a non-negative integer number of AttoPlasms.
+// The zero value is a valid zero Amount.
+type Amount struct {
+    atto *p.Decimal
+}
+
+// ================================================
+//
+// # Function 01.02 - AmountFromKoson
+//
+// AmountFromKoson creates an Amount from a Koson decimal.
+// Unlike Convert2AU, which truncates, decimals beyond CurrencyPrecision are rejected.
+func AmountFromKoson(cpAmount *p.Decimal) (Amount, error) {
+    if DecimalNotEqual(MUL(CurrencyPrecision, cpAmount, p.NFI(1)), cpAmount) == true {
+        return Amount{}, fmt.Errorf("AmountFromKoson: %s: %w", cpAmount.String(), ErrSubAtomicAmount)
+    }
+    return AmountFromAttoPlasms(MUL(0, cpAmount, AUs))
+}
+
+// ================================================
+//
+// # Function 01.03 - AmountFromAttoPlasms
+//
+// AmountFromAttoPlasms creates an Amount from an integer number of AttoPlasms.
+func AmountFromAttoPlasms(AttoPlasms *p.Decimal) (Amount, error) {
+    Integer := MUL(0, AttoPlasms, p.NFI(1))
+    if DecimalNotEqual(Integer, AttoPlasms) == true {
+        return Amount{}, fmt.Errorf("AmountFromAttoPlasms: %s: %w", AttoPlasms.String(), ErrSubAtomicAmount)
+    }
+    return checkAmount("AmountFromAttoPlasms", Integer)
+}
+
+// ================================================
+//
+// # Function 01.04 - ZeroAmount
+//
+// ZeroAmount returns an Amount of zero AttoPlasms.
+func ZeroAmount() Amount {
+    return Amount{atto: p.NFI(0)}
+}
+
+// ================================================
+//
+// checkAmount validates the AttoPlasm integer against the negative and overflow limits.
+func checkAmount(Operation string, AttoPlasms *p.Decimal) (Amount, error) {
+    if AttoPlasms.Negative == true && AttoPlasms.IsZero() == false {
+        return Amount{}, fmt.Errorf("%s: %w", Operation, ErrNegativeBalance)
+    }
+    if AttoPlasms.NumDigits() > AmountMaxDigits {
+        return Amount{}, fmt.Errorf("%s: %w", Operation, ErrAmountOverflow)
+    }
+    return Amount{atto: new(p.Decimal).Abs(AttoPlasms)}, nil
+}
+
+// ================================================================================================
+//
+//	02 Amount Arithmetic
+//		All the operations are exact integer operations on AttoPlasms.
+//
+// ================================================
+//
+// # Function 02.01 - Amount.Add
+//
+// Add returns a + b, or ErrAmountOverflow.
+func (a Amount) Add(b Amount) (Amount, error) {
+    return checkAmount("Amount.Add", ADD(0, a.AttoPlasms(), b.AttoPlasms()))
+}
+
+// ================================================
+//
+// # Function 02.02 - Amount.Sub
+//
+// Sub returns a - b, or ErrNegativeBalance if b is greater than a.
+func (a Amount) Sub(b Amount) (Amount, error) {
+    return checkAmount("Amount.Sub", SUB(0, a.AttoPlasms(), b.AttoPlasms()))
+}
+
+// ================================================
+//
+// # Function 02.03 - Amount.MulRatio
+//
+// MulRatio returns a * Numerator / Denominator, truncated to whole AttoPlasms.
+// Numerator and Denominator can be any decimals whose ratio is not negative,
+// for instance 15/100 or 0.15/1 both compute 15% of the Amount.
+func (a Amount) MulRatio(Numerator, Denominator *p.Decimal) (Amount, error) {
+    if Denominator.IsZero() == true {
+        return Amount{}, fmt.Errorf("Amount.MulRatio: %w", ErrAmountDivByZero)
+    }
+    var NumeratorDecimals uint32
+    if Numerator.Exponent < 0 {
+        NumeratorDecimals = uint32(0 - Numerator.Exponent)
+    }
+    Product := MUL(NumeratorDecimals, a.AttoPlasms(), Numerator)
+    return checkAmount("Amount.MulRatio", DIV(0, Product, Denominator))
+}
+
+// ================================================
+//
+// # Function 02.04 - Amount.Div
+//
+// Div splits the Amount into Divisor equal parts, returning the part
+// and the AttoPlasms remaining undistributed: a // Divisor and a % Divisor.
+// The Divisor must be a positive integer.
+func (a Amount) Div(Divisor *p.Decimal) (Amount, Amount, error) {
+    if Divisor.IsZero() == true {
+        return Amount{}, Amount{}, fmt.Errorf("Amount.Div: %w", ErrAmountDivByZero)
+    }
+    if Divisor.Negative == true || DecimalNotEqual(MUL(0, Divisor, p.NFI(1)), Divisor) == true {
+        return Amount{}, Amount{}, fmt.Errorf("Amount.Div: %s: %w", Divisor.String(), ErrAmountNotInteger)
+    }
+    Integer := MUL(0, Divisor, p.NFI(1))
+    Quotient := DIV(0, a.AttoPlasms(), Integer)
+    Remainder := SUB(0, a.AttoPlasms(), MUL(0, Quotient, Integer))
+    return Amount{atto: Quotient}, Amount{atto: Remainder}, nil
+}
+
+// ================================================================================================
+//
+//	03 Amount Accessors
+//
+// ================================================
+//
+// # Function 03.01 - Amount.AttoPlasms
+//
+// AttoPlasms returns the number of AttoPlasms of the Amount, as an integer decimal.
+func (a Amount) AttoPlasms() *p.Decimal {
+    if a.atto == nil {
+        return p.NFI(0)
+    }
+    return new(p.Decimal).Set(a.atto)
+}
+
+// ================================================
+//
+// # Function 03.02 - Amount.Koson
+//
+// Koson returns the value of the Amount in Koson, having CurrencyPrecision decimals.
+func (a Amount) Koson() *p.Decimal {
+    return DIV(CurrencyPrecision, a.AttoPlasms(), AUs)
+}
+
+// ================================================
+//
+// # Function 03.03 - Amount.Cmp
+//
+// Cmp compares the Amounts and returns -1 if a < b, 0 if a == b and +1 if a > b
+func (a Amount) Cmp(b Amount) int {
+    return a.AttoPlasms().Cmp(b.AttoPlasms())
+}
+
+// ================================================
+//
+// # Function 03.04 - Amount.IsZero
+//
+// IsZero returns true if the Amount has zero AttoPlasms.
+func (a Amount) IsZero() bool {
+    return a.AttoPlasms().IsZero()
+}
+
+// ================================================
+//
+// # Function 03.05 - Amount.String
+//
+// String prints the Amount using KosonicDecimalConversion,
+// for instance 123,[432|564|123][546|789|786]
+func (a Amount) String() string {
+    return KosonicDecimalConversion(a.Koson())
+}
@@ -0,0 +1,224 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "encoding/binary"
+    "fmt"
+    "math"
+    "math/big"
+)
+
+//
+//	        BinaryEncoding.go			Compact Deterministic Binary Encoding of Decimals
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Binary Encoding Functions
+//			01  - EncodeDecimal			Encodes a decimal into its canonical bytes
+//			02  - DecodeDecimal			Decodes canonical bytes, rejecting any other encoding
+//		02 Binary Marshaling
+//			01  - Decimal.MarshalBinary		Implements encoding.BinaryMarshaler using EncodeDecimal
+//			02  - Decimal.UnmarshalBinary		Implements encoding.BinaryUnmarshaler using DecodeDecimal
+//			03  - Amount.MarshalBinary		Encodes the AttoPlasms of the Amount
+//			04  - Amount.UnmarshalBinary		Decodes the AttoPlasms of the Amount
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Binary Encoding Functions
+//		Layout: the coefficient as an unsigned varint of its zigzag value (2c for c >= 0, -2c - 1
+//		for c < 0), followed by the exponent as a zigzag varint (encoding/binary PutVarint).
+//		Varints are little endian groups of 7 bits, the high bit marking that more bytes follow.
+//		Trailing zeros are moved from the coefficient into the exponent (1.50 and 15E-1 are
+//		both encoded as coefficient 15, exponent -1) and zero is always coefficient 0, exponent 0,
+//		so every numeric value has a single encoding that can be hashed and signed.
+//
+// ================================================
+//
+// # Function 01.01 - EncodeDecimal
+//
+// EncodeDecimal returns the canonical encoding of the decimal. For instance 1.5 is encoded
+// as 0x1e 0x01 (coefficient 15 zigzagged to 30, exponent -1 zigzagged to 1).
+func EncodeDecimal(Number *p.Decimal) ([]byte, error) {
+    if Number == nil || Number.Form != p.Finite {
+        return nil, fmt.Errorf("EncodeDecimal: only finite decimals can be encoded")
+    }
+    Coefficient, Exponent := normalizedParts(Number)
+    
+    //Zigzag of the coefficient
+    Zigzag := new(big.Int).Lsh(Coefficient, 1)
+    if Coefficient.Sign() < 0 {
+        Zigzag.Neg(Zigzag)
+        Zigzag.Sub(Zigzag, big.NewInt(1))
+    }
+    Output := appendBigUvarint(nil, Zigzag)
+    return binary.AppendVarint(Output, Exponent), nil
+}
+
+// ================================================
+//
+// # Function 01.02 - DecodeDecimal
+//
+// DecodeDecimal decodes bytes produced by EncodeDecimal. Non-canonical encodings
+// (overlong varints, coefficients ending in zero, zero with an exponent, trailing bytes)
+// are rejected, so that decoding and re-encoding always gives back the same bytes.
+func DecodeDecimal(Data []byte) (*p.Decimal, error) {
+    Zigzag, Read, err := readBigUvarint(Data)
+    if err != nil {
+        return nil, fmt.Errorf("DecodeDecimal: coefficient: %w", err)
+    }
+    Exponent, ExponentRead := binary.Varint(Data[Read:])
+    if ExponentRead <= 0 {
+        return nil, fmt.Errorf("DecodeDecimal: invalid exponent varint")
+    }
+    if ExponentRead != len(binary.AppendVarint(nil, Exponent)) {
+        return nil, fmt.Errorf("DecodeDecimal: overlong exponent varint")
+    }
+    if Read+ExponentRead != len(Data) {
+        return nil, fmt.Errorf("DecodeDecimal: %d trailing bytes", len(Data)-Read-ExponentRead)
+    }
+    if Exponent < math.MinInt32 || Exponent > math.MaxInt32 {
+        return nil, fmt.Errorf("DecodeDecimal: exponent %d out of range", Exponent)
+    }
+    
+    //Reversing the zigzag of the coefficient
+    Negative := Zigzag.Bit(0) == 1
+    Coefficient := new(big.Int).Rsh(Zigzag, 1)
+    if Negative == true {
+        Coefficient.Add(Coefficient, big.NewInt(1))
+    }
+    switch {
+    case Coefficient.Sign() == 0 && Exponent != 0:
+        return nil, fmt.Errorf("DecodeDecimal: zero must have a zero exponent")
+    case Coefficient.Sign() != 0 && new(big.Int).Mod(Coefficient, big.NewInt(10)).Sign() == 0:
+        return nil, fmt.Errorf("DecodeDecimal: coefficient has trailing zeros")
+    }
+    
+    Result := new(p.Decimal)
+    Result.Coeff.Set(Coefficient)
+    Result.Exponent = int32(Exponent)
+    Result.Negative = Negative
+    return Result, nil
+}
+
+// ================================================================================================
+//
+//	02 Binary Marshaling
+//
+// ================================================
+//
+// # Function 02.01 - Decimal.MarshalBinary
+//
+// MarshalBinary implements encoding.BinaryMarshaler using EncodeDecimal.
+func (d Decimal) MarshalBinary() ([]byte, error) {
+    Data, err := EncodeDecimal(d.Number)
+    if err != nil {
+        return nil, fmt.Errorf("Decimal.MarshalBinary: %w", err)
+    }
+    return Data, nil
+}
+
+// ================================================
+//
+// # Function 02.02 - Decimal.UnmarshalBinary
+//
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using DecodeDecimal.
+func (d *Decimal) UnmarshalBinary(Data []byte) error {
+    Number, err := DecodeDecimal(Data)
+    if err != nil {
+        return fmt.Errorf("Decimal.UnmarshalBinary: %w", err)
+    }
+    d.Number = Number
+    return nil
+}
+
+// ================================================
+//
+// # Function 02.03 - Amount.MarshalBinary
+//
+// MarshalBinary encodes the integer number of AttoPlasms of the Amount using EncodeDecimal.
+func (a Amount) MarshalBinary() ([]byte, error) {
+    Data, err := EncodeDecimal(a.AttoPlasms())
+    if err != nil {
+        return nil, fmt.Errorf("Amount.MarshalBinary: %w", err)
+    }
+    return Data, nil
+}
+
+// ================================================
+//
+// # Function 02.04 - Amount.UnmarshalBinary
+//
+// UnmarshalBinary decodes an integer number of AttoPlasms, rejecting fractions and negative values.
+func (a *Amount) UnmarshalBinary(Data []byte) error {
+    Number, err := DecodeDecimal(Data)
+    if err != nil {
+        return fmt.Errorf("Amount.UnmarshalBinary: %w", err)
+    }
+    Value, err := AmountFromAttoPlasms(Number)
+    if err != nil {
+        return fmt.Errorf("Amount.UnmarshalBinary: %w", err)
+    }
+    *a = Value
+    return nil
+}
+
+// ================================================
+//
+// normalizedParts returns the signed coefficient without trailing zeros and the matching exponent.
+func normalizedParts(Number *p.Decimal) (*big.Int, int64) {
+    Coefficient := new(big.Int).Set(&Number.Coeff)
+    Exponent := int64(Number.Exponent)
+    if Coefficient.Sign() == 0 {
+        return Coefficient, 0
+    }
+    Ten, Rest := big.NewInt(10), new(big.Int)
+    for {
+        Quotient, Remainder := new(big.Int).QuoRem(Coefficient, Ten, Rest)
+        if Remainder.Sign() != 0 {
+            break
+        }
+        Coefficient, Exponent = Quotient, Exponent+1
+    }
+    if Number.Negative == true {
+        Coefficient.Neg(Coefficient)
+    }
+    return Coefficient, Exponent
+}
+
+// ================================================
+//
+// appendBigUvarint appends the unsigned varint encoding of a non-negative big integer.
+func appendBigUvarint(Output []byte, Value *big.Int) []byte {
+    Rest := new(big.Int).Set(Value)
+    Mask := big.NewInt(0x7f)
+    for Rest.Cmp(Mask) > 0 {
+        Group := new(big.Int).And(Rest, Mask).Uint64()
+        Output = append(Output, byte(Group)|0x80)
+        Rest.Rsh(Rest, 7)
+    }
+    return append(Output, byte(Rest.Uint64()))
+}
+
+// ================================================
+//
+// readBigUvarint reads an unsigned varint of any length, returning the number of bytes read.
+// Overlong encodings (a last byte of zero after other bytes) are rejected.
+func readBigUvarint(Data []byte) (*big.Int, int, error) {
+    Value := new(big.Int)
+    for i, Byte := range Data {
+        Value.Or(Value, new(big.Int).Lsh(big.NewInt(int64(Byte&0x7f)), uint(7*i)))
+        if Byte < 0x80 {
+            if Byte == 0 && i > 0 {
+                return nil, 0, fmt.Errorf("overlong varint")
+            }
+            return Value, i + 1, nil
+        }
+    }
+    return nil, 0, fmt.Errorf("truncated varint")
+}
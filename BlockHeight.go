@@ -0,0 +1,212 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "fmt"
+    "math"
+    "time"
+)
+
+//
+//	        BlockHeight.go				Block Height Arithmetic and Time Conversion
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Block Height Type
+//			01  - BlockHeight			Height of a block, the first block having height 1
+//			02  - BlockHeight.Decimal		Returns the height as a decimal
+//			03  - BlockHeight.String		Prints the height using Block2Print
+//		02 Era and Epoch Functions
+//			01  - BlockHeight.Era			Returns the era index of the height
+//			02  - BlockHeight.Epoch			Returns the epoch index of the height
+//			03  - BlockHeight.BlocksRemaining	Returns the blocks remaining in the era of the height
+//		03 Time Functions
+//			01  - BlockHeight.EstimatedTime		Estimates the time of a block from the genesis time
+//			02  - DurationBetween			Estimates the duration between two heights
+//			03  - FormatBlockDuration		Formats a duration as days and hours
+//		04 Bulk Formatting
+//			01  - FormatHeights			Formats multiple heights using Block2Print
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Block Height Type
+//		Eras and epochs are groups of a fixed number of blocks, indexed from 0:
+//		with 1000 blocks per era, heights 1 to 1000 are in era 0, 1001 to 2000 in era 1.
+//		Eras of varying length are handled by EmissionSchedule.EraOf.
+//
+// ================================================
+//
+// # Type 01.01 - BlockHeight
+//
+// BlockHeight is the height of a block, the first block having height 1.
+type BlockHeight int64
+
+// ================================================
+//
+// # Function 01.02 - BlockHeight.Decimal
+//
+// Decimal returns the height as a decimal.
+func (h BlockHeight) Decimal() *p.Decimal {
+    return p.NFI(int64(h))
+}
+
+// ================================================
+//
+// # Function 01.03 - BlockHeight.String
+//
+// String prints the height using Block2Print, for instance [3.215.432]
+func (h BlockHeight) String() string {
+    return Block2Print(h.Decimal())
+}
+
+// ================================================================================================
+//
+//	02 Era and Epoch Functions
+//
+// ================================================
+//
+// # Function 02.01 - BlockHeight.Era
+//
+// Era returns the index of the era containing the height, eras having EraBlocks blocks,
+// for instance CryptoPlasmEraBlocks.
+func (h BlockHeight) Era(EraBlocks int64) (int64, error) {
+    if err := checkHeightGroup(h, EraBlocks); err != nil {
+        return 0, fmt.Errorf("BlockHeight.Era: %w", err)
+    }
+    return (int64(h) - 1) / EraBlocks, nil
+}
+
+// ================================================
+//
+// # Function 02.02 - BlockHeight.Epoch
+//
+// Epoch returns the index of the epoch containing the height, epochs having EpochBlocks blocks.
+func (h BlockHeight) Epoch(EpochBlocks int64) (int64, error) {
+    if err := checkHeightGroup(h, EpochBlocks); err != nil {
+        return 0, fmt.Errorf("BlockHeight.Epoch: %w", err)
+    }
+    return (int64(h) - 1) / EpochBlocks, nil
+}
+
+// ================================================
+//
+// # Function 02.03 - BlockHeight.BlocksRemaining
+//
+// BlocksRemaining returns the number of blocks following the height in its era,
+// eras having EraBlocks blocks. The last block of an era has 0 blocks remaining.
+func (h BlockHeight) BlocksRemaining(EraBlocks int64) (int64, error) {
+    if err := checkHeightGroup(h, EraBlocks); err != nil {
+        return 0, fmt.Errorf("BlockHeight.BlocksRemaining: %w", err)
+    }
+    return EraBlocks - 1 - (int64(h)-1)%EraBlocks, nil
+}
+
+// ================================================================================================
+//
+//	03 Time Functions
+//
+// ================================================
+//
+// # Function 03.01 - BlockHeight.EstimatedTime
+//
+// EstimatedTime estimates the time of the block, given the Genesis time of block 1
+// and the target Interval between blocks. It returns an error when the duration
+// from block 1 overflows time.Duration.
+func (h BlockHeight) EstimatedTime(Genesis time.Time, Interval time.Duration) (time.Time, error) {
+    Duration, err := DurationBetween(1, h, Interval)
+    if err != nil {
+        return time.Time{}, fmt.Errorf("BlockHeight.EstimatedTime: %w", err)
+    }
+    return Genesis.Add(Duration), nil
+}
+
+// ================================================
+//
+// # Function 03.02 - DurationBetween
+//
+// DurationBetween estimates the duration between the From and To heights,
+// given the target Interval between blocks. It is negative when To is below From.
+// Durations beyond time.Duration, about 292 years, return an error.
+func DurationBetween(From, To BlockHeight, Interval time.Duration) (time.Duration, error) {
+    Blocks := int64(To) - int64(From)
+    if (int64(To)^int64(From))&(int64(To)^Blocks) < 0 {
+        return 0, fmt.Errorf("DurationBetween: the number of blocks between %d and %d overflows", int64(From), int64(To))
+    }
+    if Blocks == 0 || Interval == 0 {
+        return 0, nil
+    }
+    if Blocks == math.MinInt64 || Interval == math.MinInt64 || absInt64(Blocks) > math.MaxInt64/absInt64(int64(Interval)) {
+        return 0, fmt.Errorf("DurationBetween: %d blocks of %s overflow the maximum duration", Blocks, Interval)
+    }
+    return time.Duration(Blocks) * Interval, nil
+}
+
+// ================================================
+//
+// absInt64 returns the absolute value of a number other than math.MinInt64.
+func absInt64(Number int64) int64 {
+    if Number < 0 {
+        return -Number
+    }
+    return Number
+}
+
+// ================================================
+//
+// # Function 03.03 - FormatBlockDuration
+//
+// FormatBlockDuration formats the duration as whole days and hours,
+// truncating the minutes, for instance "3 days 4 hours" or "-1 day 0 hours".
+func FormatBlockDuration(Duration time.Duration) string {
+    Sign := ""
+    if Duration < 0 {
+        Sign = "-"
+        Duration = -Duration
+    }
+    Days := int64(Duration / (24 * time.Hour))
+    Hours := int64((Duration % (24 * time.Hour)) / time.Hour)
+    DayUnit, HourUnit := "days", "hours"
+    if Days == 1 {
+        DayUnit = "day"
+    }
+    if Hours == 1 {
+        HourUnit = "hour"
+    }
+    return fmt.Sprintf("%s%d %s %d %s", Sign, Days, DayUnit, Hours, HourUnit)
+}
+
+// ================================================================================================
+//
+//	04 Bulk Formatting
+//
+// ================================================
+//
+// # Function 04.01 - FormatHeights
+//
+// FormatHeights formats every height using Block2Print.
+func FormatHeights(Heights []BlockHeight) []string {
+    Result := make([]string, len(Heights))
+    for i, Height := range Heights {
+        Result[i] = Height.String()
+    }
+    return Result
+}
+
+// ================================================
+//
+// checkHeightGroup validates the height and the number of blocks of an era or epoch.
+func checkHeightGroup(h BlockHeight, GroupBlocks int64) error {
+    if h < 1 {
+        return fmt.Errorf("invalid block height %d", int64(h))
+    }
+    if GroupBlocks <= 0 {
+        return fmt.Errorf("the number of blocks must be positive, got %d", GroupBlocks)
+    }
+    return nil
+}
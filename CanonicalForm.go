@@ -0,0 +1,72 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "fmt"
+    "math"
+)
+
+//
+//	        CanonicalForm.go			Canonical String Form for Hashing and Signatures
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Canonical Form Functions
+//			01  - CanonicalString			Returns the single canonical text of a decimal value
+//			02  - ParseCanonical			Parses canonical text, rejecting any other form
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Canonical Form Functions
+//		DTS, String() and KosonicDecimalConversion can give different texts for the same value
+//		(1.50 and 1.5, 1E+3 and 1000). The canonical form has a single text per value:
+//
+//		Canonical   = "0" | [ "-" ] Integer [ "." Fraction ] | [ "-" ] "0." Fraction
+//		Integer     = NonZeroDigit { Digit }
+//		Fraction    = { Digit } NonZeroDigit
+//
+//		That is: no trailing zeros after the point, no point without decimals, no leading zeros,
+//		no exponent, no "+" sign, "-" only for negative values and "0" for zero.
+//
+// ================================================
+//
+// # Function 01.01 - CanonicalString
+//
+// CanonicalString returns the canonical text of the decimal:
+// 1.50 gives "1.5", 1E+3 gives "1000", -0.000 gives "0".
+// Non-finite decimals have no canonical form, their String() being returned,
+// which ParseCanonical rejects.
+func CanonicalString(Number *p.Decimal) string {
+    if Number.Form != p.Finite {
+        return Number.String()
+    }
+    Coefficient, Exponent := normalizedParts(Number)
+    Reduced := new(p.Decimal)
+    Reduced.Coeff.Abs(Coefficient)
+    Reduced.Exponent = int32(Exponent)
+    Reduced.Negative = Coefficient.Sign() < 0
+    return DTS(Reduced)
+}
+
+// ================================================
+//
+// # Function 01.02 - ParseCanonical
+//
+// ParseCanonical parses canonical text into a decimal. Any text that is not exactly
+// the CanonicalString of its value ("1.50", "01", "+1", "-0", "1e3", " 1") is rejected.
+func ParseCanonical(Text string) (*p.Decimal, error) {
+    Number, err := ParsePlainDecimal(Text, math.MaxUint32)
+    if err != nil {
+        return nil, fmt.Errorf("ParseCanonical: %w", err)
+    }
+    if Canonical := CanonicalString(Number); Canonical != Text {
+        return nil, fmt.Errorf("ParseCanonical: %q is not canonical, expected %q", Text, Canonical)
+    }
+    return Number, nil
+}
@@ -0,0 +1,229 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "errors"
+)
+
+//
+//	        ContinuedFractions.go			Continued Fractions and Best Rational Approximations
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Fraction Type
+//			01  - Fraction				Numerator/Denominator pair made of integer decimals
+//			02  - Fraction.Value			Converts the Fraction into a decimal
+//			03  - Fraction.String			Prints the Fraction as "Numerator/Denominator"
+//			04  - DecimalToFraction			Converts a decimal into its exact Fraction
+//		02 Continued Fraction Functions
+//			01  - ToContinuedFraction		Returns the terms of the continued fraction of a decimal
+//			02  - FromContinuedFraction		Computes the decimal value of a continued fraction
+//			03  - Convergents			Returns the convergents of a continued fraction
+//		03 Rational Approximation Functions
+//			01  - BestRationalApproximation		Closest fraction to a decimal having a bounded denominator
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Fraction Type
+//
+// ================================================
+//
+// # Type 01.01 - Fraction
+//
+// Fraction is a rational number made of two integer decimals.
+// The Denominator is always positive, the sign being carried by the Numerator.
+type Fraction struct {
+    Numerator   *p.Decimal
+    Denominator *p.Decimal
+}
+
+// ================================================
+//
+// # Function 01.02 - Fraction.Value
+//
+// Value returns the decimal value of the Fraction,
+// truncated to "DecimalPrecision" decimals.
+func (f Fraction) Value(DecimalPrecision uint32) *p.Decimal {
+    return DIV(DecimalPrecision, f.Numerator, f.Denominator)
+}
+
+// ================================================
+//
+// # Function 01.03 - Fraction.String
+//
+// String prints the Fraction as "Numerator/Denominator", for instance "-355/113"
+func (f Fraction) String() string {
+    return f.Numerator.String() + "/" + f.Denominator.String()
+}
+
+// ================================================
+//
+// # Function 01.04 - DecimalToFraction
+//
+// DecimalToFraction returns the exact Fraction represented by the decimal,
+// not reduced to its lowest terms: 1.25 becomes 125/100.
+func DecimalToFraction(Number *p.Decimal) Fraction {
+    if Number.Exponent >= 0 {
+        return Fraction{Numerator: MUL(0, Number, p.NFI(1)), Denominator: p.NFI(1)}
+    }
+    Denominator := MUL(0, p.NFI(1), p.New(1, 0-Number.Exponent))
+    return Fraction{Numerator: MUL(0, Number, Denominator), Denominator: Denominator}
+}
+
+// ================================================================================================
+//
+//	02 Continued Fraction Functions
+//		The continued fraction of a decimal is computed exactly, with the Euclidean algorithm
+//		on its Fraction, therefore it is always finite.
+//		[a0; a1, a2, ...] stands for a0 + 1/(a1 + 1/(a2 + ...))
+//
+// ================================================
+//
+// # Function 02.01 - ToContinuedFraction
+//
+// ToContinuedFraction returns the terms [a0; a1, a2, ...] of the continued fraction of the decimal.
+// The first term is the floor of the number (and can be negative or zero),
+// all the other terms are positive integers.
+// At most "MaxTerms" terms are returned; a MaxTerms lower than 1 returns all the terms.
+func ToContinuedFraction(Number *p.Decimal, MaxTerms int) []*p.Decimal {
+    var (
+        Terms       = make([]*p.Decimal, 0)
+        Zero        = p.NFI(0)
+        Exact       = DecimalToFraction(Number)
+        Numerator   = Exact.Numerator
+        Denominator = Exact.Denominator
+    )
+    
+    for MaxTerms < 1 || len(Terms) < MaxTerms {
+        //Floor division, DivInt truncates toward zero.
+        Term := TruncateCustom(DivInt(Numerator, Denominator), 0)
+        Rest := SUB(0, Numerator, MUL(0, Term, Denominator))
+        if DecimalLessThan(Rest, Zero) == true {
+            Term = SUB(0, Term, p.NFI(1))
+            Rest = ADD(0, Rest, Denominator)
+        }
+        Terms = append(Terms, Term)
+        if Rest.IsZero() == true {
+            break
+        }
+        Numerator, Denominator = Denominator, Rest
+    }
+    return Terms
+}
+
+// ================================================
+//
+// # Function 02.02 - FromContinuedFraction
+//
+// FromContinuedFraction returns the decimal value of the continued fraction [a0; a1, a2, ...],
+// truncated to "DecimalPrecision" decimals.
+func FromContinuedFraction(DecimalPrecision uint32, Terms []*p.Decimal) (*p.Decimal, error) {
+    if len(Terms) == 0 {
+        return nil, errors.New("FromContinuedFraction: no terms were given")
+    }
+    Fractions := Convergents(Terms)
+    Convergent := Fractions[len(Fractions)-1]
+    if Convergent.Denominator.IsZero() == true {
+        return nil, errors.New("FromContinuedFraction: the continued fraction has a zero denominator")
+    }
+    return Convergent.Value(DecimalPrecision), nil
+}
+
+// ================================================
+//
+// # Function 02.03 - Convergents
+//
+// Convergents returns the successive convergents h(n)/k(n) of the continued fraction,
+// computed with the recurrences:
+// h(n) = a(n) * h(n-1) + h(n-2)
+// k(n) = a(n) * k(n-1) + k(n-2)
+// starting from h(-1)/k(-1) = 1/0 and h(-2)/k(-2) = 0/1.
+func Convergents(Terms []*p.Decimal) []Fraction {
+    var (
+        Result                    = make([]Fraction, 0)
+        NumeratorPrevious         = p.NFI(1)
+        NumeratorBeforePrevious   = p.NFI(0)
+        DenominatorPrevious       = p.NFI(0)
+        DenominatorBeforePrevious = p.NFI(1)
+    )
+    for _, Term := range Terms {
+        Numerator := ADD(0, MUL(0, Term, NumeratorPrevious), NumeratorBeforePrevious)
+        Denominator := ADD(0, MUL(0, Term, DenominatorPrevious), DenominatorBeforePrevious)
+        Result = append(Result, Fraction{Numerator: Numerator, Denominator: Denominator})
+        
+        NumeratorBeforePrevious, NumeratorPrevious = NumeratorPrevious, Numerator
+        DenominatorBeforePrevious, DenominatorPrevious = DenominatorPrevious, Denominator
+    }
+    return Result
+}
+
+// ================================================================================================
+//
+//	03 Rational Approximation Functions
+//
+// ================================================
+//
+// # Function 03.01 - BestRationalApproximation
+//
+// BestRationalApproximation returns the fraction closest to the decimal
+// whose denominator is not greater than MaxDenominator, for instance
+// 3.14159265 with a MaxDenominator of 1000 gives 355/113.
+// The candidates are the last convergent within the bound and the best
+// semi-convergent that follows it; on equal distance the smaller denominator wins.
+func BestRationalApproximation(Number *p.Decimal, MaxDenominator int64) (Fraction, error) {
+    if MaxDenominator < 1 {
+        return Fraction{}, errors.New("BestRationalApproximation: the maximum denominator must be at least 1")
+    }
+    
+    var (
+        Bound                     = p.NFI(MaxDenominator)
+        Exact                     = DecimalToFraction(Number)
+        Terms                     = ToContinuedFraction(Number, 0)
+        NumeratorPrevious         = p.NFI(1)
+        NumeratorBeforePrevious   = p.NFI(0)
+        DenominatorPrevious       = p.NFI(0)
+        DenominatorBeforePrevious = p.NFI(1)
+    )
+    
+    //Distance returns |Number - f| scaled by the common positive factor Exact.Denominator,
+    //as a fraction whose value can be compared exactly.
+    Distance := func(f Fraction) Fraction {
+        Difference := SUB(0, MUL(0, Exact.Numerator, f.Denominator), MUL(0, f.Numerator, Exact.Denominator))
+        return Fraction{Numerator: new(p.Decimal).Abs(Difference), Denominator: f.Denominator}
+    }
+    
+    for _, Term := range Terms {
+        Numerator := ADD(0, MUL(0, Term, NumeratorPrevious), NumeratorBeforePrevious)
+        Denominator := ADD(0, MUL(0, Term, DenominatorPrevious), DenominatorBeforePrevious)
+        
+        if DecimalGreaterThan(Denominator, Bound) == true {
+            //Best semi-convergent within the bound
+            Convergent := Fraction{Numerator: NumeratorPrevious, Denominator: DenominatorPrevious}
+            Steps := TruncateCustom(DivInt(SUB(0, Bound, DenominatorBeforePrevious), DenominatorPrevious), 0)
+            if DecimalLessThan(Steps, p.NFI(1)) == true {
+                return Convergent, nil
+            }
+            SemiConvergent := Fraction{
+                Numerator:   ADD(0, NumeratorBeforePrevious, MUL(0, Steps, NumeratorPrevious)),
+                Denominator: ADD(0, DenominatorBeforePrevious, MUL(0, Steps, DenominatorPrevious)),
+            }
+            D1, D2 := Distance(SemiConvergent), Distance(Convergent)
+            if DecimalLessThan(MUL(0, D1.Numerator, D2.Denominator), MUL(0, D2.Numerator, D1.Denominator)) == true {
+                return SemiConvergent, nil
+            }
+            return Convergent, nil
+        }
+        
+        NumeratorBeforePrevious, NumeratorPrevious = NumeratorPrevious, Numerator
+        DenominatorBeforePrevious, DenominatorPrevious = DenominatorPrevious, Denominator
+    }
+    
+    //The continued fraction ended within the bound, the approximation is exact.
+    return Fraction{Numerator: NumeratorPrevious, Denominator: DenominatorPrevious}, nil
+}
@@ -0,0 +1,243 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "bufio"
+    "encoding/csv"
+    "fmt"
+    "io"
+    "os"
+    "strings"
+)
+
+//
+//	        DecimalCSV.go				CSV and TSV Import and Export of Decimal Lists and Tables
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Table Types
+//			01  - DecimalFormatter			Converts a decimal into text
+//			02  - DecimalTable			Header and rows of decimals
+//			03  - FormatDTS				DecimalFormatter using DTS
+//			04  - FormatKosonic			DecimalFormatter using KosonicDecimalConversion
+//			05  - ScientificFormatter		Returns a DecimalFormatter using FormatScientific
+//		02 Reading Functions
+//			01  - ReadDecimalList			Reads a file holding one decimal per line
+//			02  - ReadDecimalListFrom		Reads one decimal per line from a reader
+//			03  - ReadDecimalTable			Reads a CSV or TSV file of decimals
+//			04  - ReadDecimalTableFrom		Reads a CSV or TSV table of decimals from a reader
+//		03 Writing Functions
+//			01  - WriteDecimalTable			Writes a table of decimals into a CSV or TSV file
+//			02  - WriteDecimalTableTo		Writes a table of decimals into a writer
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Table Types
+//		Values are read with ParsePlainDecimal (MaxMathPrecision decimals at most),
+//		errors giving the line and column of the offending value. The Comma sets the
+//		field separator: ',' for CSV and '\t' for TSV.
+//
+// ================================================
+//
+// # Type 01.01 - DecimalFormatter
+//
+// DecimalFormatter converts a decimal into text.
+type DecimalFormatter func(Number *p.Decimal) string
+
+// ================================================
+//
+// # Type 01.02 - DecimalTable
+//
+// DecimalTable holds an optional Header and Rows of decimals, all rows having the same length.
+type DecimalTable struct {
+    Header []string
+    Rows   [][]*p.Decimal
+}
+
+// ================================================
+//
+// # Function 01.03 - FormatDTS
+//
+// FormatDTS formats the decimal using DTS, for instance 1234.5
+func FormatDTS(Number *p.Decimal) string {
+    return DTS(Number)
+}
+
+// ================================================
+//
+// # Function 01.04 - FormatKosonic
+//
+// FormatKosonic formats the decimal using KosonicDecimalConversion, for instance 1.234,[500|000|000][000|000|000]
+func FormatKosonic(Number *p.Decimal) string {
+    return KosonicDecimalConversion(Number)
+}
+
+// ================================================
+//
+// # Function 01.05 - ScientificFormatter
+//
+// ScientificFormatter returns a DecimalFormatter using FormatScientific with SigFigs significant figures.
+func ScientificFormatter(SigFigs int) DecimalFormatter {
+    return func(Number *p.Decimal) string {
+        return FormatScientific(Number, SigFigs)
+    }
+}
+
+// ================================================================================================
+//
+//	02 Reading Functions
+//
+// ================================================
+//
+// # Function 02.01 - ReadDecimalList
+//
+// ReadDecimalList reads the file at Path, holding one decimal per line. Blank lines are skipped.
+func ReadDecimalList(Path string) ([]*p.Decimal, error) {
+    File, err := os.Open(Path)
+    if err != nil {
+        return nil, fmt.Errorf("ReadDecimalList: %w", err)
+    }
+    defer File.Close()
+    return ReadDecimalListFrom(File)
+}
+
+// ================================================
+//
+// # Function 02.02 - ReadDecimalListFrom
+//
+// ReadDecimalListFrom reads one decimal per line from the Reader. Blank lines are skipped.
+func ReadDecimalListFrom(Reader io.Reader) ([]*p.Decimal, error) {
+    var List []*p.Decimal
+    Scanner := bufio.NewScanner(Reader)
+    Line := 0
+    for Scanner.Scan() {
+        Line++
+        Text := strings.TrimSpace(Scanner.Text())
+        if Text == "" {
+            continue
+        }
+        Number, err := ParsePlainDecimal(Text, MaxMathPrecision)
+        if err != nil {
+            return nil, fmt.Errorf("ReadDecimalList: line %d: %w", Line, err)
+        }
+        List = append(List, Number)
+    }
+    if err := Scanner.Err(); err != nil {
+        return nil, fmt.Errorf("ReadDecimalList: %w", err)
+    }
+    return List, nil
+}
+
+// ================================================
+//
+// # Function 02.03 - ReadDecimalTable
+//
+// ReadDecimalTable reads the CSV or TSV file at Path. When HasHeader is true,
+// the first record is stored as the table Header instead of being parsed.
+func ReadDecimalTable(Path string, Comma rune, HasHeader bool) (DecimalTable, error) {
+    File, err := os.Open(Path)
+    if err != nil {
+        return DecimalTable{}, fmt.Errorf("ReadDecimalTable: %w", err)
+    }
+    defer File.Close()
+    return ReadDecimalTableFrom(File, Comma, HasHeader)
+}
+
+// ================================================
+//
+// # Function 02.04 - ReadDecimalTableFrom
+//
+// ReadDecimalTableFrom reads a CSV or TSV table from the Reader, like ReadDecimalTable.
+func ReadDecimalTableFrom(Reader io.Reader, Comma rune, HasHeader bool) (DecimalTable, error) {
+    var Table DecimalTable
+    CSV := csv.NewReader(Reader)
+    CSV.Comma = Comma
+    CSV.ReuseRecord = true
+    for {
+        Record, err := CSV.Read()
+        if err == io.EOF {
+            break
+        }
+        if err != nil {
+            return DecimalTable{}, fmt.Errorf("ReadDecimalTable: %w", err)
+        }
+        Line, _ := CSV.FieldPos(0)
+        if HasHeader == true && Table.Header == nil {
+            Table.Header = append([]string(nil), Record...)
+            continue
+        }
+        Row := make([]*p.Decimal, len(Record))
+        for Column, Field := range Record {
+            Row[Column], err = ParsePlainDecimal(strings.TrimSpace(Field), MaxMathPrecision)
+            if err != nil {
+                return DecimalTable{}, fmt.Errorf("ReadDecimalTable: line %d, column %d: %w", Line, Column+1, err)
+            }
+        }
+        Table.Rows = append(Table.Rows, Row)
+    }
+    return Table, nil
+}
+
+// ================================================================================================
+//
+//	03 Writing Functions
+//
+// ================================================
+//
+// # Function 03.01 - WriteDecimalTable
+//
+// WriteDecimalTable writes the table into the CSV or TSV file at Path, the Header first if set.
+// Column i is formatted with Formatters[i]; columns without a formatter use FormatDTS.
+func WriteDecimalTable(Path string, Table DecimalTable, Comma rune, Formatters []DecimalFormatter) error {
+    File, err := os.Create(Path)
+    if err != nil {
+        return fmt.Errorf("WriteDecimalTable: %w", err)
+    }
+    if err := WriteDecimalTableTo(File, Table, Comma, Formatters); err != nil {
+        _ = File.Close()
+        return err
+    }
+    if err := File.Close(); err != nil {
+        return fmt.Errorf("WriteDecimalTable: %w", err)
+    }
+    return nil
+}
+
+// ================================================
+//
+// # Function 03.02 - WriteDecimalTableTo
+//
+// WriteDecimalTableTo writes the table into the Writer, like WriteDecimalTable.
+func WriteDecimalTableTo(Writer io.Writer, Table DecimalTable, Comma rune, Formatters []DecimalFormatter) error {
+    CSV := csv.NewWriter(Writer)
+    CSV.Comma = Comma
+    if Table.Header != nil {
+        if err := CSV.Write(Table.Header); err != nil {
+            return fmt.Errorf("WriteDecimalTable: %w", err)
+        }
+    }
+    for _, Row := range Table.Rows {
+        Record := make([]string, len(Row))
+        for Column, Number := range Row {
+            Formatter := DecimalFormatter(FormatDTS)
+            if Column < len(Formatters) && Formatters[Column] != nil {
+                Formatter = Formatters[Column]
+            }
+            Record[Column] = Formatter(Number)
+        }
+        if err := CSV.Write(Record); err != nil {
+            return fmt.Errorf("WriteDecimalTable: %w", err)
+        }
+    }
+    CSV.Flush()
+    if err := CSV.Error(); err != nil {
+        return fmt.Errorf("WriteDecimalTable: %w", err)
+    }
+    return nil
+}
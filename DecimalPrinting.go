@@ -0,0 +1,235 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "fmt"
+    "io"
+    "os"
+    "strings"
+    "unicode/utf8"
+)
+
+//
+//	        DecimalPrinting.go			io.Writer based Printing of Decimal Lists and Tables
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 List Printing
+//			01  - FprintDecimalList			Writes the decimals of a slice, one per line
+//			02  - FwriteList			Writes the strings of a slice, one per line
+//			03  - WriteListFile			Writes the strings of a slice into a file
+//		02 Aligned Printing
+//			01  - FprintAlignedList			Writes decimals in a column aligned on the decimal point
+//			02  - FprintDecimalTable		Writes a table with columns aligned on the decimal point
+//			03  - AlignOnPoint			Pads texts so that their decimal points are aligned
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 List Printing
+//		Variants of PrintDecimalList and WriteList writing to any io.Writer and returning
+//		the errors instead of printing them. A nil DecimalFormatter uses FormatDTS.
+//
+// ================================================
+//
+// # Function 01.01 - FprintDecimalList
+//
+// FprintDecimalList writes the decimals of the slice to the Writer, one per line, using the Formatter.
+func FprintDecimalList(Writer io.Writer, List []*p.Decimal, Formatter DecimalFormatter) error {
+    if Formatter == nil {
+        Formatter = FormatDTS
+    }
+    for i, Number := range List {
+        if _, err := fmt.Fprintln(Writer, Formatter(Number)); err != nil {
+            return fmt.Errorf("FprintDecimalList: element %d: %w", i, err)
+        }
+    }
+    return nil
+}
+
+// ================================================
+//
+// # Function 01.02 - FwriteList
+//
+// FwriteList writes the strings of the slice to the Writer, one per line.
+func FwriteList(Writer io.Writer, List []string) error {
+    for i, Line := range List {
+        if _, err := fmt.Fprintln(Writer, Line); err != nil {
+            return fmt.Errorf("FwriteList: line %d: %w", i, err)
+        }
+    }
+    return nil
+}
+
+// ================================================
+//
+// # Function 01.03 - WriteListFile
+//
+// WriteListFile writes the strings of the slice into the file Name, one per line,
+// like WriteList does, but returning the errors.
+func WriteListFile(Name string, List []string) error {
+    File, err := os.Create(Name)
+    if err != nil {
+        return fmt.Errorf("WriteListFile: %w", err)
+    }
+    if err := FwriteList(File, List); err != nil {
+        _ = File.Close()
+        return fmt.Errorf("WriteListFile: %w", err)
+    }
+    if err := File.Close(); err != nil {
+        return fmt.Errorf("WriteListFile: %w", err)
+    }
+    return nil
+}
+
+// ================================================================================================
+//
+//	02 Aligned Printing
+//		Texts are aligned on the first occurrence of the decimal Point ("." for FormatDTS,
+//		"," for FormatKosonic): integer parts are right aligned, decimals are left aligned.
+//		Texts without the Point are split where their leading sign and digits end, so integers
+//		are aligned as integers, while Kosonic values below 1, printed without integer part
+//		and Point ("[500|000|000]..." for 0.5), have a space in place of the Point.
+//
+// ================================================
+//
+// # Function 02.01 - FprintAlignedList
+//
+// FprintAlignedList writes the decimals to the Writer, one per line, formatted with the
+// Formatter and aligned on the decimal Point:
+//
+//	  12.5
+//	-1234.25
+//	     7
+func FprintAlignedList(Writer io.Writer, List []*p.Decimal, Formatter DecimalFormatter, Point string) error {
+    if Formatter == nil {
+        Formatter = FormatDTS
+    }
+    Texts := make([]string, len(List))
+    for i, Number := range List {
+        Texts[i] = Formatter(Number)
+    }
+    for i, Line := range AlignOnPoint(Texts, Point) {
+        if _, err := fmt.Fprintln(Writer, strings.TrimRight(Line, " ")); err != nil {
+            return fmt.Errorf("FprintAlignedList: line %d: %w", i, err)
+        }
+    }
+    return nil
+}
+
+// ================================================
+//
+// # Function 02.02 - FprintDecimalTable
+//
+// FprintDecimalTable writes the table to the Writer for terminal reports, columns being separated
+// by two spaces and aligned on the decimal Point. Column i is formatted with Formatters[i];
+// columns without a formatter use FormatDTS. Header names are right aligned.
+func FprintDecimalTable(Writer io.Writer, Table DecimalTable, Formatters []DecimalFormatter, Point string) error {
+    NumberOfColumns := len(Table.Header)
+    for _, Row := range Table.Rows {
+        if len(Row) > NumberOfColumns {
+            NumberOfColumns = len(Row)
+        }
+    }
+    Columns := make([][]string, NumberOfColumns)
+    for Column := range Columns {
+        Formatter := DecimalFormatter(FormatDTS)
+        if Column < len(Formatters) && Formatters[Column] != nil {
+            Formatter = Formatters[Column]
+        }
+        Columns[Column] = make([]string, len(Table.Rows))
+        for Row, Numbers := range Table.Rows {
+            if Column < len(Numbers) {
+                Columns[Column][Row] = Formatter(Numbers[Column])
+            }
+        }
+    }
+    
+    //Aligning every column, then padding it and its header to the same width.
+    Widths := make([]int, len(Columns))
+    for Column := range Columns {
+        Columns[Column] = AlignOnPoint(Columns[Column], Point)
+        if len(Columns[Column]) > 0 {
+            Widths[Column] = utf8.RuneCountInString(Columns[Column][0])
+        }
+        if Column < len(Table.Header) && utf8.RuneCountInString(Table.Header[Column]) > Widths[Column] {
+            Widths[Column] = utf8.RuneCountInString(Table.Header[Column])
+        }
+    }
+    WriteLine := func(Cells []string, RightAlign bool) error {
+        Padded := make([]string, len(Cells))
+        for Column, Cell := range Cells {
+            Padding := strings.Repeat(" ", Widths[Column]-utf8.RuneCountInString(Cell))
+            if RightAlign == true {
+                Padded[Column] = Padding + Cell
+            } else {
+                Padded[Column] = Cell + Padding
+            }
+        }
+        _, err := fmt.Fprintln(Writer, strings.TrimRight(strings.Join(Padded, "  "), " "))
+        return err
+    }
+    
+    if Table.Header != nil {
+        Header := make([]string, len(Columns))
+        copy(Header, Table.Header)
+        if err := WriteLine(Header, true); err != nil {
+            return fmt.Errorf("FprintDecimalTable: header: %w", err)
+        }
+    }
+    for Row := range Table.Rows {
+        Cells := make([]string, len(Columns))
+        for Column := range Columns {
+            Cells[Column] = Columns[Column][Row]
+        }
+        if err := WriteLine(Cells, false); err != nil {
+            return fmt.Errorf("FprintDecimalTable: row %d: %w", Row, err)
+        }
+    }
+    return nil
+}
+
+// ================================================
+//
+// # Function 02.03 - AlignOnPoint
+//
+// AlignOnPoint pads the texts with spaces, on both sides, so that their decimal Points
+// are in the same position and they all have the same length.
+func AlignOnPoint(Texts []string, Point string) []string {
+    var (
+        IntegerWidth  int
+        FractionWidth int
+        Integers      = make([]string, len(Texts))
+        Fractions     = make([]string, len(Texts))
+        Aligned       = make([]string, len(Texts))
+    )
+    for i, Text := range Texts {
+        Index := strings.Index(Text, Point)
+        if Point == "" || Index < 0 {
+            Index = strings.IndexFunc(Text, func(r rune) bool { return strings.ContainsRune("+-0123456789", r) == false })
+            if Index < 0 {
+                Index = len(Text)
+            }
+        }
+        Integers[i], Fractions[i] = Text[:Index], Text[Index:]
+        if Fractions[i] != "" && strings.HasPrefix(Fractions[i], Point) == false {
+            Fractions[i] = strings.Repeat(" ", utf8.RuneCountInString(Point)) + Fractions[i]
+        }
+        if Width := utf8.RuneCountInString(Integers[i]); Width > IntegerWidth {
+            IntegerWidth = Width
+        }
+        if Width := utf8.RuneCountInString(Fractions[i]); Width > FractionWidth {
+            FractionWidth = Width
+        }
+    }
+    for i := range Texts {
+        Aligned[i] = strings.Repeat(" ", IntegerWidth-utf8.RuneCountInString(Integers[i])) + Integers[i] +
+            Fractions[i] + strings.Repeat(" ", FractionWidth-utf8.RuneCountInString(Fractions[i]))
+    }
+    return Aligned
+}
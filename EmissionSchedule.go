@@ -0,0 +1,298 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "fmt"
+)
+
+//
+//	        EmissionSchedule.go			Block Emission Schedule Calculator
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Emission Types
+//			01  - EmissionEra			Block count and linear reward of an era
+//			02  - EmissionSchedule			Sequence of eras making up the emission
+//		02 Emission Functions
+//			01  - EmissionSchedule.Validate		Checks the era parameters
+//			02  - EmissionSchedule.EraOf		Returns the era of a block height and its position within the era
+//			03  - EmissionSchedule.Reward		Returns the reward of a block
+//			04  - EmissionSchedule.Supply		Returns the cumulative supply up to a block
+//			05  - EmissionSchedule.HeightForSupply	Returns the first block at which the supply reaches an amount
+//			06  - EmissionSchedule.EraTotals	Returns the amount emitted in each era
+//			07  - EmissionSchedule.TotalSupply	Returns the amount emitted by all the eras
+//			08  - EmissionSchedule.SummedSupply	Returns the cumulative supply by adding every block reward
+//			09  - EmissionSchedule.CrossCheck	Compares Supply with SummedSupply
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Emission Types
+//		Blocks are numbered from 1. Within an era the block reward changes linearly,
+//		by RewardStep from one block to the next. Rewards having at most CurrencyPrecision
+//		decimals, the cumulative supply is computed exactly with the arithmetic series sum.
+//		After the last era no more rewards are emitted.
+//		Only the ERA length of CryptoPlasm is provided: its reward curve and the OverSend
+//		computation mentioned by the Logarithm comments are not defined in this library,
+//		so no CryptoPlasm schedule preset is given.
+//
+// ================================================
+//
+// CryptoPlasmEraBlocks is the number of blocks of a CryptoPlasm ERA.
+const CryptoPlasmEraBlocks = int64(524596891)
+
+// ================================================
+//
+// # Type 01.01 - EmissionEra
+//
+// EmissionEra holds the number of Blocks of an era, the reward of its first block (StartReward)
+// and the change of the reward from one block to the next (RewardStep, negative for decreasing rewards).
+type EmissionEra struct {
+    Blocks      int64
+    StartReward *p.Decimal
+    RewardStep  *p.Decimal
+}
+
+// ================================================
+//
+// # Type 01.02 - EmissionSchedule
+//
+// EmissionSchedule is the sequence of eras, the first one starting at block 1.
+type EmissionSchedule struct {
+    Eras []EmissionEra
+}
+
+// ================================================================================================
+//
+//	02 Emission Functions
+//
+// ================================================
+//
+// # Function 02.01 - EmissionSchedule.Validate
+//
+// Validate checks that every era has blocks, that rewards have at most CurrencyPrecision
+// decimals and that no block reward is negative.
+func (s EmissionSchedule) Validate() error {
+    if len(s.Eras) == 0 {
+        return fmt.Errorf("EmissionSchedule: no eras defined")
+    }
+    for i, Era := range s.Eras {
+        if Era.Blocks <= 0 {
+            return fmt.Errorf("EmissionSchedule: era %d has %d blocks", i+1, Era.Blocks)
+        }
+        if Era.StartReward == nil || Era.RewardStep == nil {
+            return fmt.Errorf("EmissionSchedule: era %d has no reward set", i+1)
+        }
+        for _, Value := range []*p.Decimal{Era.StartReward, Era.RewardStep} {
+            if DecimalNotEqual(MUL(CurrencyPrecision, Value, p.NFI(1)), Value) == true {
+                return fmt.Errorf("EmissionSchedule: era %d reward %s has more than %d decimals", i+1, Value.String(), CurrencyPrecision)
+            }
+        }
+        if DecimalLessThan(Era.StartReward, p.NFI(0)) == true || DecimalLessThan(eraReward(Era, Era.Blocks-1), p.NFI(0)) == true {
+            return fmt.Errorf("EmissionSchedule: era %d has negative block rewards", i+1)
+        }
+    }
+    return nil
+}
+
+// ================================================
+//
+// # Function 02.02 - EmissionSchedule.EraOf
+//
+// EraOf returns the index of the era (0 for the first one) containing the block Height,
+// and the position of the block within the era (0 for its first block).
+// Heights after the last era return len(Eras) as index.
+func (s EmissionSchedule) EraOf(Height int64) (int, int64, error) {
+    if Height < 1 {
+        return 0, 0, fmt.Errorf("EmissionSchedule: invalid block height %d", Height)
+    }
+    Offset := Height - 1
+    for i, Era := range s.Eras {
+        if Offset < Era.Blocks {
+            return i, Offset, nil
+        }
+        Offset = Offset - Era.Blocks
+    }
+    return len(s.Eras), Offset, nil
+}
+
+// ================================================
+//
+// # Function 02.03 - EmissionSchedule.Reward
+//
+// Reward returns the reward of the block Height, zero after the last era.
+func (s EmissionSchedule) Reward(Height int64) (*p.Decimal, error) {
+    if err := s.Validate(); err != nil {
+        return nil, err
+    }
+    Era, Offset, err := s.EraOf(Height)
+    if err != nil {
+        return nil, err
+    }
+    if Era == len(s.Eras) {
+        return p.NFI(0), nil
+    }
+    return eraReward(s.Eras[Era], Offset), nil
+}
+
+// ================================================
+//
+// # Function 02.04 - EmissionSchedule.Supply
+//
+// Supply returns the amount emitted by the blocks 1 to Height, both included.
+// A zero Height returns zero.
+func (s EmissionSchedule) Supply(Height int64) (*p.Decimal, error) {
+    if err := s.Validate(); err != nil {
+        return nil, err
+    }
+    if Height < 0 {
+        return nil, fmt.Errorf("EmissionSchedule: invalid block height %d", Height)
+    }
+    Supply := p.NFI(0)
+    Remaining := Height
+    for _, Era := range s.Eras {
+        if Remaining <= 0 {
+            break
+        }
+        Blocks := Era.Blocks
+        if Remaining < Blocks {
+            Blocks = Remaining
+        }
+        Supply = ADD(CurrencyPrecision, Supply, eraSum(Era, Blocks))
+        Remaining = Remaining - Blocks
+    }
+    return Supply, nil
+}
+
+// ================================================
+//
+// # Function 02.05 - EmissionSchedule.HeightForSupply
+//
+// HeightForSupply returns the first block Height at which Supply reaches the Amount,
+// or an error if the TotalSupply is lower than the Amount.
+func (s EmissionSchedule) HeightForSupply(Amount *p.Decimal) (int64, error) {
+    if err := s.Validate(); err != nil {
+        return 0, err
+    }
+    if DecimalLessThanOrEqual(Amount, p.NFI(0)) == true {
+        return 0, nil
+    }
+    Emitted := p.NFI(0)
+    Start := int64(0)
+    for _, Era := range s.Eras {
+        EraTotal := eraSum(Era, Era.Blocks)
+        if DecimalLessThan(ADD(CurrencyPrecision, Emitted, EraTotal), Amount) == true {
+            Emitted = ADD(CurrencyPrecision, Emitted, EraTotal)
+            Start = Start + Era.Blocks
+            continue
+        }
+        //Bisection over the blocks of the era, the supply never decreasing.
+        Needed := SUB(CurrencyPrecision, Amount, Emitted)
+        Low, High := int64(1), Era.Blocks
+        for Low < High {
+            Middle := Low + (High-Low)/2
+            if DecimalGreaterThanOrEqual(eraSum(Era, Middle), Needed) == true {
+                High = Middle
+            } else {
+                Low = Middle + 1
+            }
+        }
+        return Start + Low, nil
+    }
+    return 0, fmt.Errorf("EmissionSchedule: the total supply %s never reaches %s", Emitted.String(), Amount.String())
+}
+
+// ================================================
+//
+// # Function 02.06 - EmissionSchedule.EraTotals
+//
+// EraTotals returns the amount emitted in each era.
+func (s EmissionSchedule) EraTotals() ([]*p.Decimal, error) {
+    if err := s.Validate(); err != nil {
+        return nil, err
+    }
+    Totals := make([]*p.Decimal, len(s.Eras))
+    for i, Era := range s.Eras {
+        Totals[i] = eraSum(Era, Era.Blocks)
+    }
+    return Totals, nil
+}
+
+// ================================================
+//
+// # Function 02.07 - EmissionSchedule.TotalSupply
+//
+// TotalSupply returns the amount emitted by all the eras.
+func (s EmissionSchedule) TotalSupply() (*p.Decimal, error) {
+    Totals, err := s.EraTotals()
+    if err != nil {
+        return nil, err
+    }
+    return SUM(CurrencyPrecision, p.NFI(0), Totals...), nil
+}
+
+// ================================================
+//
+// # Function 02.08 - EmissionSchedule.SummedSupply
+//
+// SummedSupply returns the amount emitted by the blocks 1 to Height by adding
+// every block reward, one by one. It is slow, being meant for cross-checking Supply.
+func (s EmissionSchedule) SummedSupply(Height int64) (*p.Decimal, error) {
+    if err := s.Validate(); err != nil {
+        return nil, err
+    }
+    if Height < 0 {
+        return nil, fmt.Errorf("EmissionSchedule: invalid block height %d", Height)
+    }
+    Supply := p.NFI(0)
+    Remaining := Height
+    for _, Era := range s.Eras {
+        for Offset := int64(0); Offset < Era.Blocks && Remaining > 0; Offset++ {
+            Supply = ADD(CurrencyPrecision, Supply, eraReward(Era, Offset))
+            Remaining--
+        }
+    }
+    return Supply, nil
+}
+
+// ================================================
+//
+// # Function 02.09 - EmissionSchedule.CrossCheck
+//
+// CrossCheck returns an error if Supply and SummedSupply disagree at the block Height.
+func (s EmissionSchedule) CrossCheck(Height int64) error {
+    Computed, err := s.Supply(Height)
+    if err != nil {
+        return err
+    }
+    Summed, err := s.SummedSupply(Height)
+    if err != nil {
+        return err
+    }
+    if DecimalNotEqual(Computed, Summed) == true {
+        return fmt.Errorf("EmissionSchedule: supply %s differs from summed supply %s at height %d", Computed.String(), Summed.String(), Height)
+    }
+    return nil
+}
+
+// ================================================
+//
+// eraReward returns the reward of the block at position Offset within the era.
+func eraReward(Era EmissionEra, Offset int64) *p.Decimal {
+    return ADD(CurrencyPrecision, Era.StartReward, MUL(CurrencyPrecision, Era.RewardStep, p.NFI(Offset)))
+}
+
+// ================================================
+//
+// eraSum returns the amount emitted by the first Blocks blocks of the era:
+// Blocks * StartReward + RewardStep * Blocks * (Blocks - 1) / 2
+func eraSum(Era EmissionEra, Blocks int64) *p.Decimal {
+    n := p.NFI(Blocks)
+    Pairs := DIV(0, MUL(0, n, p.NFI(Blocks-1)), p.NFI(2))
+    return ADD(CurrencyPrecision, MUL(CurrencyPrecision, n, Era.StartReward), MUL(CurrencyPrecision, Era.RewardStep, Pairs))
+}
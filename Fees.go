@@ -0,0 +1,303 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "fmt"
+)
+
+//
+//	        Fees.go					Promille and Percentage Fee Calculator
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Fee Types
+//			01  - FeeRounding			Rounding direction of computed fees
+//			02  - FeeTier				A fee rate applied from a given amount upwards
+//			03  - FeeSchedule			Tiered fee rates with minimum and maximum clamps
+//		02 Fee Functions
+//			01  - FeeFromPromille			Computes a fee given in promille (‰)
+//			02  - FeeFromPercent			Computes a fee given in percent (%)
+//			03  - AmountAfterFee			Subtracts a fee from an amount
+//			04  - ClampFee				Clamps a fee between a minimum and a maximum
+//			05  - FlatFeeSchedule			Returns a single rate FeeSchedule
+//			06  - FeeSchedule.Fee			Computes the fee for an amount using a FeeSchedule
+//			07  - GrossUpForNetAmount		Computes the amount to send so that the recipient receives a net amount
+//			08  - RoundCurrency			Rounds a decimal to CurrencyPrecision in the given direction
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Fee Types
+//		Fee rates are taken with PromillePrecision decimals (as TruncPercent does),
+//		fees are returned with CurrencyPrecision decimals, rounded in an explicit direction.
+//
+// ================================================
+//
+// # Type 01.01 - FeeRounding
+//
+// FeeRounding sets the rounding direction of fees to CurrencyPrecision.
+type FeeRounding int
+
+const (
+    FeeRoundDown FeeRounding = iota // Truncation, the rest of the AttoPlasm is in the favour of the payer
+    FeeRoundUp                      // The rest of the AttoPlasm is in the favour of the fee collector
+)
+
+// ================================================
+//
+// # Type 01.02 - FeeTier
+//
+// FeeTier applies the Promille rate to amounts starting From the given value.
+type FeeTier struct {
+    From     *p.Decimal
+    Promille *p.Decimal
+}
+
+// ================================================
+//
+// # Type 01.03 - FeeSchedule
+//
+// FeeSchedule holds fee tiers, sorted ascending by their From amount, the first one starting at zero.
+// When Marginal is false the rate of the highest reached tier applies to the whole amount,
+// otherwise each rate applies only to the part of the amount that lies within its tier.
+// Min and Max clamp the computed fee, and are ignored when nil.
+type FeeSchedule struct {
+    Tiers    []FeeTier
+    Marginal bool
+    Min      *p.Decimal
+    Max      *p.Decimal
+    Rounding FeeRounding
+}
+
+// ================================================================================================
+//
+//	02 Fee Functions
+//
+// ================================================
+//
+// # Function 02.01 - FeeFromPromille
+//
+// FeeFromPromille returns Amount * Promille / 1000, rounded to CurrencyPrecision in the given direction.
+// The Promille rate is truncated to PromillePrecision decimals.
+// A fee of 2.5‰ from 1000 is 2.5, while 1‰ from 0.000000000000000999 is 0 rounded down and 1E-18 rounded up.
+func FeeFromPromille(Amount, Promille *p.Decimal, Rounding FeeRounding) (*p.Decimal, error) {
+    Zero := p.NFI(0)
+    if DecimalLessThan(Amount, Zero) == true {
+        return nil, fmt.Errorf("FeeFromPromille: negative amount %s", Amount.String())
+    }
+    if DecimalLessThan(Promille, Zero) == true {
+        return nil, fmt.Errorf("FeeFromPromille: negative rate %s", Promille.String())
+    }
+    Rate := MUL(PromillePrecision, Promille, p.NFI(1))
+    //Amount * Rate has at most CurrencyPrecision + PromillePrecision decimals,
+    //the division by 1000 adding 3 more, so the fee is computed exactly before rounding.
+    Truncated := MUL(CurrencyPrecision, Amount, p.NFI(1))
+    Exact := DIV(CurrencyPrecision+PromillePrecision+3, MUL(CurrencyPrecision+PromillePrecision, Truncated, Rate), p.NFI(1000))
+    return RoundCurrency(Exact, Rounding), nil
+}
+
+// ================================================
+//
+// # Function 02.02 - FeeFromPercent
+//
+// FeeFromPercent returns Amount * Percent / 100, rounded to CurrencyPrecision in the given direction.
+// The Percent rate is converted to promille, thus being truncated to PromillePrecision + 1 decimals.
+func FeeFromPercent(Amount, Percent *p.Decimal, Rounding FeeRounding) (*p.Decimal, error) {
+    Fee, err := FeeFromPromille(Amount, MUL(PromillePrecision, Percent, p.NFI(10)), Rounding)
+    if err != nil {
+        return nil, fmt.Errorf("FeeFromPercent: %w", err)
+    }
+    return Fee, nil
+}
+
+// ================================================
+//
+// # Function 02.03 - AmountAfterFee
+//
+// AmountAfterFee returns Amount - Fee, truncated to CurrencyPrecision.
+// An error is returned when the Fee is negative or exceeds the Amount.
+func AmountAfterFee(Amount, Fee *p.Decimal) (*p.Decimal, error) {
+    if DecimalLessThan(Fee, p.NFI(0)) == true {
+        return nil, fmt.Errorf("AmountAfterFee: negative fee %s", Fee.String())
+    }
+    if DecimalGreaterThan(Fee, Amount) == true {
+        return nil, fmt.Errorf("AmountAfterFee: fee %s exceeds the amount %s", Fee.String(), Amount.String())
+    }
+    return MUL(CurrencyPrecision, SUB(CurrencyPrecision, Amount, Fee), p.NFI(1)), nil
+}
+
+// ================================================
+//
+// # Function 02.04 - ClampFee
+//
+// ClampFee returns the Fee limited to the [Min, Max] interval, nil limits being ignored.
+// The result is a new decimal truncated to CurrencyPrecision.
+// An error is returned when Min is greater than Max.
+func ClampFee(Fee, Min, Max *p.Decimal) (*p.Decimal, error) {
+    if Min != nil && Max != nil && DecimalGreaterThan(Min, Max) == true {
+        return nil, fmt.Errorf("ClampFee: minimum %s exceeds the maximum %s", Min.String(), Max.String())
+    }
+    Clamped := Fee
+    if Min != nil && DecimalLessThan(Fee, Min) == true {
+        Clamped = Min
+    }
+    if Max != nil && DecimalGreaterThan(Fee, Max) == true {
+        Clamped = Max
+    }
+    return MUL(CurrencyPrecision, Clamped, p.NFI(1)), nil
+}
+
+// ================================================
+//
+// # Function 02.05 - FlatFeeSchedule
+//
+// FlatFeeSchedule returns a FeeSchedule applying the same Promille rate to any amount.
+func FlatFeeSchedule(Promille *p.Decimal, Rounding FeeRounding) FeeSchedule {
+    return FeeSchedule{Tiers: []FeeTier{{From: p.NFI(0), Promille: Promille}}, Rounding: Rounding}
+}
+
+// ================================================
+//
+// # Function 02.06 - FeeSchedule.Fee
+//
+// Fee computes the fee for the Amount using the schedule tiers, then clamps it between Min and Max.
+// A fee of 10‰ up to 1000 and 5‰ from 1000 upwards gives, for 3000,
+// a fee of 15 when Marginal is false and 10 + 10 = 20 when Marginal is true.
+// The clamped fee never exceeds the Amount.
+func (s FeeSchedule) Fee(Amount *p.Decimal) (*p.Decimal, error) {
+    if len(s.Tiers) == 0 {
+        return nil, fmt.Errorf("FeeSchedule.Fee: the schedule has no tiers")
+    }
+    if DecimalNotEqual(s.Tiers[0].From, p.NFI(0)) == true {
+        return nil, fmt.Errorf("FeeSchedule.Fee: the first tier must start at zero")
+    }
+    for i := 1; i < len(s.Tiers); i++ {
+        if DecimalLessThanOrEqual(s.Tiers[i].From, s.Tiers[i-1].From) == true {
+            return nil, fmt.Errorf("FeeSchedule.Fee: tier %d does not start above tier %d", i, i-1)
+        }
+    }
+    
+    var (
+        Fee = p.NFI(0)
+        err error
+    )
+    if s.Marginal == false {
+        Tier := s.Tiers[0]
+        for _, t := range s.Tiers {
+            if DecimalGreaterThanOrEqual(Amount, t.From) == true {
+                Tier = t
+            }
+        }
+        Fee, err = FeeFromPromille(Amount, Tier.Promille, s.Rounding)
+    } else {
+        //The tier fees are computed exactly, the rounding being done once, on their sum.
+        for i, t := range s.Tiers {
+            if DecimalLessThanOrEqual(Amount, t.From) == true {
+                break
+            }
+            Upper := Amount
+            if i+1 < len(s.Tiers) && DecimalLessThan(s.Tiers[i+1].From, Amount) == true {
+                Upper = s.Tiers[i+1].From
+            }
+            Portion := SUB(MaxMathPrecision, Upper, t.From)
+            if DecimalLessThan(t.Promille, p.NFI(0)) == true {
+                return nil, fmt.Errorf("FeeSchedule.Fee: negative rate %s in tier %d", t.Promille.String(), i)
+            }
+            Rate := MUL(PromillePrecision, t.Promille, p.NFI(1))
+            Fee = ADD(MaxMathPrecision, Fee, DIV(MaxMathPrecision, MUL(MaxMathPrecision, Portion, Rate), p.NFI(1000)))
+        }
+        Fee = RoundCurrency(Fee, s.Rounding)
+    }
+    if err != nil {
+        return nil, fmt.Errorf("FeeSchedule.Fee: %w", err)
+    }
+    Fee, err = ClampFee(Fee, s.Min, s.Max)
+    if err != nil {
+        return nil, fmt.Errorf("FeeSchedule.Fee: %w", err)
+    }
+    if DecimalGreaterThan(Fee, Amount) == true {
+        Fee = TruncToCurrency(MUL(CurrencyPrecision, Amount, p.NFI(1)))
+    }
+    return Fee, nil
+}
+
+// ================================================
+//
+// # Function 02.07 - GrossUpForNetAmount
+//
+// GrossUpForNetAmount returns the smallest amount (with CurrencyPrecision decimals) that must be
+// sent so that the recipient receives at least the Net amount after the schedule fee is deducted.
+// For a flat 10‰ fee rounded up, a Net of 99 requires sending 100.
+// The amount received must grow with the amount sent, which holds for marginal schedules
+// and for schedules whose rates do not decrease; an error is returned when no amount
+// up to 10^MaxMathPrecision/2 is enough (for instance with a rate of 1000‰).
+func GrossUpForNetAmount(Net *p.Decimal, Schedule FeeSchedule) (*p.Decimal, error) {
+    One := p.NFI(1)
+    if DecimalLessThan(Net, p.NFI(0)) == true {
+        return nil, fmt.Errorf("GrossUpForNetAmount: negative net amount %s", Net.String())
+    }
+    //NetOf returns the amount received when Gross AttoPlasms are sent.
+    NetOf := func(GrossAU *p.Decimal) (*p.Decimal, error) {
+        Gross := DIV(CurrencyPrecision, GrossAU, AUs)
+        Fee, err := Schedule.Fee(Gross)
+        if err != nil {
+            return nil, err
+        }
+        return SUB(CurrencyPrecision, Gross, Fee), nil
+    }
+    Target := RoundCurrency(MUL(MaxMathPrecision, Net, One), FeeRoundUp)
+    
+    //Doubling the upper bound until it is enough, then bisecting in AttoPlasms.
+    Low := MUL(0, Convert2AU(Target), One)
+    High := ADD(0, Low, One)
+    for {
+        Received, err := NetOf(High)
+        if err != nil {
+            return nil, fmt.Errorf("GrossUpForNetAmount: %w", err)
+        }
+        if DecimalGreaterThanOrEqual(Received, Target) == true {
+            break
+        }
+        if High.NumDigits() > int64(MaxMathPrecision/2) {
+            return nil, fmt.Errorf("GrossUpForNetAmount: no amount is enough for a net amount of %s", Net.String())
+        }
+        Low = High
+        High = MUL(0, High, p.NFI(2))
+    }
+    for DecimalLessThan(Low, High) == true {
+        Middle := DIV(0, ADD(0, Low, High), p.NFI(2))
+        Received, err := NetOf(Middle)
+        if err != nil {
+            return nil, fmt.Errorf("GrossUpForNetAmount: %w", err)
+        }
+        if DecimalGreaterThanOrEqual(Received, Target) == true {
+            High = Middle
+        } else {
+            Low = ADD(0, Middle, One)
+        }
+    }
+    return DIV(CurrencyPrecision, High, AUs), nil
+}
+
+// ================================================
+//
+// # Function 02.08 - RoundCurrency
+//
+// RoundCurrency rounds the decimal to CurrencyPrecision decimals. FeeRoundDown truncates,
+// FeeRoundUp moves any discarded rest one AttoPlasm away from zero.
+func RoundCurrency(Number *p.Decimal, Rounding FeeRounding) *p.Decimal {
+    Truncated := MUL(CurrencyPrecision, Number, p.NFI(1))
+    if Rounding == FeeRoundUp && DecimalNotEqual(Truncated, Number) == true {
+        Step := DIV(CurrencyPrecision, p.NFI(1), AUs)
+        if Number.Negative == true {
+            Step.Neg(Step)
+        }
+        Truncated = ADD(CurrencyPrecision, Truncated, Step)
+    }
+    return Truncated
+}
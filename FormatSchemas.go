@@ -0,0 +1,532 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "fmt"
+    "strings"
+)
+
+//
+//	        FormatSchemas.go			Locale-aware Number Formatting Schemas
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Schema Types
+//			01  - MinusPlacement			Where the minus sign of negative numbers is placed
+//			02  - BracketStyle			How the decimals are grouped and bracketed
+//			03  - FormatSchema			Complete description of a number display format
+//		02 Schema Presets
+//			01  - KosonicSchema			123,[432|564|123][546|789|786]
+//			02  - SchemaEnUS			1,234,567.89
+//			03  - SchemaDeDE			1.234.567,89
+//			04  - SchemaFrFR			1 234 567,89 (narrow no-break space)
+//			05  - SchemaEnIN			12,34,567.89 (lakh and crore grouping)
+//			06  - GetFormatSchema			Returns a preset schema by its name
+//		03 Formatting Functions
+//			01  - FormatDecimal			Formats a decimal with a given number of decimals using a schema
+//			02  - FormatCurrency			Formats a decimal with CurrencyPrecision decimals using a schema
+//			03  - FormatBlock			Formats an integer (like a BlockHeight) using a schema
+//			04  - GroupIntegerDigits		Inserts the group separators into integer digits
+//			05  - GroupDecimalDigits		Splits decimal digits into bracketed groups
+//			06  - PlaceMinus			Adds the minus sign to a formatted negative number
+//		04 Parsing Functions
+//			01  - ParseFormatted			Parses a string produced by FormatDecimal
+//			02  - ParseCurrency			Parses a string produced by FormatCurrency
+//			03  - ParseKosonic			Parses a string produced by KosonicDecimalConversion
+//			04  - ParseBlock			Parses a string produced by FormatBlock
+//			05  - ParseBlock2Print			Parses a string produced by Block2Print
+//			06  - RemoveMinus			Removes the minus sign according to its placement
+//			07  - UngroupIntegerDigits		Validates and removes the integer group separators
+//			08  - UngroupDecimalDigits		Validates and removes the decimal brackets
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Schema Types
+//
+// ================================================
+//
+// # Type 01.01 - MinusPlacement
+//
+// MinusPlacement sets where the minus sign of negative numbers is placed.
+type MinusPlacement int
+
+const (
+    MinusLeading     MinusPlacement = iota // -1.234,5
+    MinusTrailing                          // 1.234,5-
+    MinusParentheses                       // (1.234,5)
+)
+
+// ================================================
+//
+// # Type 01.02 - BracketStyle
+//
+// BracketStyle describes how the decimals are displayed. The decimals are split
+// into groups of GroupSize digits, separated by Separator, and every
+// GroupsPerBracket groups are enclosed between Open and Close.
+// Block formatting uses Open and Close around the whole number.
+// An empty BracketStyle displays the decimals as a plain string of digits.
+type BracketStyle struct {
+    Open             string
+    Close            string
+    Separator        string
+    GroupSize        int
+    GroupsPerBracket int
+}
+
+// ================================================
+//
+// # Type 01.03 - FormatSchema
+//
+// FormatSchema holds every choice made when a decimal is converted into a display string.
+// Grouping is the size of the integer digit groups, starting from the decimal separator,
+// the last size being repeated: {3} gives 1.234.567 while {3, 2} gives 12,34,567.
+// An empty Grouping displays the integer part without separators.
+// OmitZeroInteger displays non-zero subunitary values only by their decimals,
+// ZeroText (if set) is displayed by FormatBlock for a zero value.
+type FormatSchema struct {
+    Name             string
+    DecimalSeparator string
+    GroupSeparator   string
+    Grouping         []int
+    Brackets         BracketStyle
+    Minus            MinusPlacement
+    OmitZeroInteger  bool
+    ZeroText         string
+}
+
+// ================================================================================================
+//
+//	02 Schema Presets
+//
+// ================================================
+var (
+    // KosonicSchema is the display format used by KosonicDecimalConversion and Block2Print
+    KosonicSchema = FormatSchema{
+        Name:             "Kosonic",
+        DecimalSeparator: ",",
+        GroupSeparator:   ".",
+        Grouping:         []int{3},
+        Brackets:         BracketStyle{Open: "[", Close: "]", Separator: "|", GroupSize: 3, GroupsPerBracket: 3},
+        Minus:            MinusLeading,
+        OmitZeroInteger:  true,
+        ZeroText:         "ZERO",
+    }
+    // SchemaEnUS uses point as decimal separator and comma as thousand separator
+    SchemaEnUS = FormatSchema{Name: "en-US", DecimalSeparator: ".", GroupSeparator: ",", Grouping: []int{3}, Minus: MinusLeading}
+    // SchemaDeDE uses comma as decimal separator and point as thousand separator
+    SchemaDeDE = FormatSchema{Name: "de-DE", DecimalSeparator: ",", GroupSeparator: ".", Grouping: []int{3}, Minus: MinusLeading}
+    // SchemaFrFR uses comma as decimal separator and a narrow no-break space as thousand separator
+    SchemaFrFR = FormatSchema{Name: "fr-FR", DecimalSeparator: ",", GroupSeparator: " ", Grouping: []int{3}, Minus: MinusLeading}
+    // SchemaEnIN groups the thousands, then lakhs and crores by 2 digits
+    SchemaEnIN = FormatSchema{Name: "en-IN", DecimalSeparator: ".", GroupSeparator: ",", Grouping: []int{3, 2}, Minus: MinusLeading}
+)
+
+// ================================================
+//
+// # Function 02.06 - GetFormatSchema
+//
+// GetFormatSchema returns the preset schema having the given name,
+// one of "Kosonic", "en-US", "de-DE", "fr-FR" or "en-IN".
+func GetFormatSchema(Name string) (FormatSchema, error) {
+    for _, Schema := range []FormatSchema{KosonicSchema, SchemaEnUS, SchemaDeDE, SchemaFrFR, SchemaEnIN} {
+        if Schema.Name == Name {
+            return Schema, nil
+        }
+    }
+    return FormatSchema{}, fmt.Errorf("GetFormatSchema: unknown schema %q", Name)
+}
+
+// ================================================================================================
+//
+//	03 Formatting Functions
+//
+// ================================================
+//
+// # Function 03.01 - FormatDecimal
+//
+// FormatDecimal converts the decimal into a display string using the schema.
+// The number is truncated to "Decimals" decimals, all of them being displayed.
+// Using KosonicSchema and 18 Decimals, 123.432564123546789786
+// is converted to 123,[432|564|123][546|789|786]
+func FormatDecimal(Number *p.Decimal, Decimals uint32, Schema FormatSchema) string {
+    var Builder strings.Builder
+    
+    //Truncation also brings the Exponent to exactly -Decimals,
+    //so the coefficient holds all the digits to be displayed.
+    Truncated := MUL(Decimals, new(p.Decimal).Abs(Number), p.NFI(1))
+    Digits := Truncated.Coeff.Text(10)
+    if len(Digits) <= int(Decimals) {
+        Digits = strings.Repeat("0", int(Decimals)-len(Digits)+1) + Digits
+    }
+    IntegerDigits := Digits[:len(Digits)-int(Decimals)]
+    DecimalDigits := Digits[len(Digits)-int(Decimals):]
+    
+    if !(Schema.OmitZeroInteger == true && IntegerDigits == "0" && Truncated.IsZero() == false) || Decimals == 0 {
+        Builder.WriteString(GroupIntegerDigits(IntegerDigits, Schema))
+        if Decimals > 0 {
+            Builder.WriteString(Schema.DecimalSeparator)
+        }
+    }
+    Builder.WriteString(GroupDecimalDigits(DecimalDigits, Schema.Brackets))
+    
+    return PlaceMinus(Builder.String(), Number.Negative == true && Truncated.IsZero() == false, Schema.Minus)
+}
+
+// ================================================
+//
+// # Function 03.02 - FormatCurrency
+//
+// FormatCurrency converts a CryptoPlasm amount into a display string using the schema,
+// displaying CurrencyPrecision decimals.
+func FormatCurrency(cpAmount *p.Decimal, Schema FormatSchema) string {
+    return FormatDecimal(cpAmount, CurrencyPrecision, Schema)
+}
+
+// ================================================
+//
+// # Function 03.03 - FormatBlock
+//
+// FormatBlock converts an integer (like a BlockHeight) into a display string using the schema.
+// Decimals, if any, are removed. The number is enclosed by the schema brackets;
+// a zero value is displayed as the schema ZeroText, when it is set.
+// Using KosonicSchema, 3215432 is converted to [3.215.432]
+func FormatBlock(Number *p.Decimal, Schema FormatSchema) string {
+    var Body string
+    
+    Integer := MUL(0, new(p.Decimal).Abs(Number), p.NFI(1))
+    if Integer.IsZero() == true && Schema.ZeroText != "" {
+        Body = Schema.ZeroText
+    } else {
+        Body = GroupIntegerDigits(Integer.Coeff.Text(10), Schema)
+    }
+    Body = Schema.Brackets.Open + Body + Schema.Brackets.Close
+    return PlaceMinus(Body, Number.Negative == true && Integer.IsZero() == false, Schema.Minus)
+}
+
+// ================================================
+//
+// # Function 03.04 - GroupIntegerDigits
+//
+// GroupIntegerDigits inserts the schema group separators into a string of integer digits.
+func GroupIntegerDigits(Digits string, Schema FormatSchema) string {
+    if len(Schema.Grouping) == 0 || Schema.GroupSeparator == "" {
+        return Digits
+    }
+    
+    var (
+        Groups []string
+        Index  int
+        End    = len(Digits)
+    )
+    for End > 0 {
+        Size := Schema.Grouping[Index]
+        if Index < len(Schema.Grouping)-1 {
+            Index++
+        }
+        Start := End - Size
+        if Size <= 0 || Start < 0 {
+            Start = 0
+        }
+        Groups = append([]string{Digits[Start:End]}, Groups...)
+        End = Start
+    }
+    return strings.Join(Groups, Schema.GroupSeparator)
+}
+
+// ================================================
+//
+// # Function 03.05 - GroupDecimalDigits
+//
+// GroupDecimalDigits splits a string of decimal digits according to the BracketStyle.
+func GroupDecimalDigits(Digits string, Style BracketStyle) string {
+    if Digits == "" {
+        return ""
+    }
+    if Style.GroupSize <= 0 {
+        return Style.Open + Digits + Style.Close
+    }
+    
+    var (
+        Builder          strings.Builder
+        GroupsPerBracket = Style.GroupsPerBracket
+        Group            = 0
+    )
+    if GroupsPerBracket <= 0 {
+        GroupsPerBracket = (len(Digits) + Style.GroupSize - 1) / Style.GroupSize
+    }
+    for Start := 0; Start < len(Digits); Start += Style.GroupSize {
+        End := Start + Style.GroupSize
+        if End > len(Digits) {
+            End = len(Digits)
+        }
+        if Group%GroupsPerBracket == 0 {
+            Builder.WriteString(Style.Open)
+        } else {
+            Builder.WriteString(Style.Separator)
+        }
+        Builder.WriteString(Digits[Start:End])
+        Group++
+        if Group%GroupsPerBracket == 0 || End == len(Digits) {
+            Builder.WriteString(Style.Close)
+        }
+    }
+    return Builder.String()
+}
+
+// ================================================
+//
+// # Function 03.06 - PlaceMinus
+//
+// PlaceMinus adds the minus sign to a formatted number, when it is negative.
+func PlaceMinus(Body string, Negative bool, Placement MinusPlacement) string {
+    if Negative == false {
+        return Body
+    }
+    switch Placement {
+    case MinusTrailing:
+        return Body + "-"
+    case MinusParentheses:
+        return "(" + Body + ")"
+    default:
+        return "-" + Body
+    }
+}
+
+// ================================================================================================
+//
+//	04 Parsing Functions
+//		Inverse of the Formatting Functions. Parsing is strict: only strings
+//		that the Formatting Functions would produce, with the same schema, are accepted,
+//		so that displayed amounts can be round-tripped exactly.
+//
+// ================================================
+//
+// # Function 04.01 - ParseFormatted
+//
+// ParseFormatted converts a string produced by FormatDecimal with the same
+// "Decimals" and schema back into a decimal. An error describing the first
+// problem found is returned for any deviation from the schema layout.
+func ParseFormatted(Text string, Decimals uint32, Schema FormatSchema) (*p.Decimal, error) {
+    var IntegerText, DecimalText string
+    
+    Body, Negative, err := RemoveMinus(Text, Schema.Minus)
+    if err != nil {
+        return nil, fmt.Errorf("ParseFormatted: %w", err)
+    }
+    if Body == "" {
+        return nil, fmt.Errorf("ParseFormatted: %q contains no digits", Text)
+    }
+    
+    //Splitting the integer and decimal parts
+    if Decimals == 0 {
+        IntegerText = Body
+    } else {
+        Separators := strings.Count(Body, Schema.DecimalSeparator)
+        switch {
+        case Separators > 1:
+            return nil, fmt.Errorf("ParseFormatted: %q contains %d decimal separators %q", Text, Separators, Schema.DecimalSeparator)
+        case Separators == 1:
+            IntegerText, DecimalText, _ = strings.Cut(Body, Schema.DecimalSeparator)
+            if IntegerText == "" {
+                return nil, fmt.Errorf("ParseFormatted: %q has no integer part before the decimal separator %q", Text, Schema.DecimalSeparator)
+            }
+        case Schema.OmitZeroInteger == true:
+            //Subunitary values are displayed only by their decimals
+            IntegerText, DecimalText = "0", Body
+        default:
+            return nil, fmt.Errorf("ParseFormatted: %q has no decimal separator %q", Text, Schema.DecimalSeparator)
+        }
+    }
+    
+    IntegerDigits, err := UngroupIntegerDigits(IntegerText, Schema)
+    if err != nil {
+        return nil, fmt.Errorf("ParseFormatted: %q: %w", Text, err)
+    }
+    DecimalDigits, err := UngroupDecimalDigits(DecimalText, int(Decimals), Schema.Brackets)
+    if err != nil {
+        return nil, fmt.Errorf("ParseFormatted: %q: %w", Text, err)
+    }
+    
+    Result := p.NFS(IntegerDigits)
+    if Decimals > 0 {
+        Result = p.NFS(IntegerDigits + "." + DecimalDigits)
+    }
+    if Negative == true {
+        Result.Neg(Result)
+    }
+    
+    //Final check, covering the cases the layout alone cannot decide,
+    //like "-0" or a zero integer part displayed where it should have been omitted.
+    if Expected := FormatDecimal(Result, Decimals, Schema); Expected != Text {
+        return nil, fmt.Errorf("ParseFormatted: %q is not in %s format, expected %q", Text, Schema.Name, Expected)
+    }
+    return Result, nil
+}
+
+// ================================================
+//
+// # Function 04.02 - ParseCurrency
+//
+// ParseCurrency converts a string produced by FormatCurrency
+// with the same schema back into a decimal.
+func ParseCurrency(Text string, Schema FormatSchema) (*p.Decimal, error) {
+    return ParseFormatted(Text, CurrencyPrecision, Schema)
+}
+
+// ================================================
+//
+// # Function 04.03 - ParseKosonic
+//
+// ParseKosonic is the inverse of KosonicDecimalConversion:
+// 123,[432|564|123][546|789|786] is converted back to 123.432564123546789786
+func ParseKosonic(Text string) (*p.Decimal, error) {
+    return ParseCurrency(Text, KosonicSchema)
+}
+
+// ================================================
+//
+// # Function 04.04 - ParseBlock
+//
+// ParseBlock converts a string produced by FormatBlock
+// with the same schema back into an integer decimal.
+func ParseBlock(Text string, Schema FormatSchema) (*p.Decimal, error) {
+    Body, Negative, err := RemoveMinus(Text, Schema.Minus)
+    if err != nil {
+        return nil, fmt.Errorf("ParseBlock: %w", err)
+    }
+    if strings.HasPrefix(Body, Schema.Brackets.Open) == false || strings.HasSuffix(Body, Schema.Brackets.Close) == false ||
+        len(Body) < len(Schema.Brackets.Open)+len(Schema.Brackets.Close) {
+        return nil, fmt.Errorf("ParseBlock: %q must be enclosed by %q and %q", Text, Schema.Brackets.Open, Schema.Brackets.Close)
+    }
+    Body = Body[len(Schema.Brackets.Open) : len(Body)-len(Schema.Brackets.Close)]
+    
+    Result := p.NFI(0)
+    if Schema.ZeroText == "" || Body != Schema.ZeroText {
+        Digits, err := UngroupIntegerDigits(Body, Schema)
+        if err != nil {
+            return nil, fmt.Errorf("ParseBlock: %q: %w", Text, err)
+        }
+        Result = p.NFS(Digits)
+    }
+    if Negative == true {
+        Result.Neg(Result)
+    }
+    
+    if Expected := FormatBlock(Result, Schema); Expected != Text {
+        return nil, fmt.Errorf("ParseBlock: %q is not in %s format, expected %q", Text, Schema.Name, Expected)
+    }
+    return Result, nil
+}
+
+// ================================================
+//
+// # Function 04.05 - ParseBlock2Print
+//
+// ParseBlock2Print is the inverse of Block2Print:
+// [3.215.432] is converted back to 3215432 and [ZERO] to 0
+func ParseBlock2Print(Text string) (*p.Decimal, error) {
+    return ParseBlock(Text, KosonicSchema)
+}
+
+// ================================================
+//
+// # Function 04.06 - RemoveMinus
+//
+// RemoveMinus removes the minus sign from a formatted number according to its placement,
+// reporting whether the number was negative.
+func RemoveMinus(Text string, Placement MinusPlacement) (string, bool, error) {
+    switch Placement {
+    case MinusTrailing:
+        if strings.HasSuffix(Text, "-") {
+            return Text[:len(Text)-1], true, nil
+        }
+    case MinusParentheses:
+        Open, Close := strings.HasPrefix(Text, "("), strings.HasSuffix(Text, ")")
+        if Open != Close || Open == true && len(Text) < 2 {
+            return "", false, fmt.Errorf("unbalanced parentheses in %q", Text)
+        }
+        if Open == true {
+            return Text[1 : len(Text)-1], true, nil
+        }
+    default:
+        if strings.HasPrefix(Text, "-") {
+            return Text[1:], true, nil
+        }
+    }
+    return Text, false, nil
+}
+
+// ================================================
+//
+// # Function 04.07 - UngroupIntegerDigits
+//
+// UngroupIntegerDigits validates the group separators of an integer part,
+// as inserted by GroupIntegerDigits, and returns the bare digits.
+func UngroupIntegerDigits(Text string, Schema FormatSchema) (string, error) {
+    var Groups = []string{Text}
+    if len(Schema.Grouping) > 0 && Schema.GroupSeparator != "" {
+        Groups = strings.Split(Text, Schema.GroupSeparator)
+    }
+    
+    Index := 0
+    for i := len(Groups) - 1; i >= 0; i-- {
+        Group := Groups[i]
+        if Group == "" {
+            return "", fmt.Errorf("empty digit group in integer part %q", Text)
+        }
+        for _, Character := range Group {
+            if Character < '0' || Character > '9' {
+                return "", fmt.Errorf("invalid character %q in integer part %q", Character, Text)
+            }
+        }
+        if len(Groups) > 1 {
+            Size := Schema.Grouping[Index]
+            if Index < len(Schema.Grouping)-1 {
+                Index++
+            }
+            if i > 0 && len(Group) != Size || i == 0 && len(Group) > Size {
+                return "", fmt.Errorf("digit group %q of integer part %q must have %d digits", Group, Text, Size)
+            }
+        }
+    }
+    
+    Digits := strings.Join(Groups, "")
+    if len(Digits) > 1 && Digits[0] == '0' {
+        return "", fmt.Errorf("integer part %q has leading zeros", Text)
+    }
+    return Digits, nil
+}
+
+// ================================================
+//
+// # Function 04.08 - UngroupDecimalDigits
+//
+// UngroupDecimalDigits validates the bracket layout of a decimal part,
+// as produced by GroupDecimalDigits, and returns the bare digits.
+// Exactly "Decimals" digits must be present.
+func UngroupDecimalDigits(Text string, Decimals int, Style BracketStyle) (string, error) {
+    Digits := Text
+    for _, Mark := range []string{Style.Open, Style.Close, Style.Separator} {
+        if Mark != "" {
+            Digits = strings.ReplaceAll(Digits, Mark, "")
+        }
+    }
+    for _, Character := range Digits {
+        if Character < '0' || Character > '9' {
+            return "", fmt.Errorf("invalid character %q in decimal part %q", Character, Text)
+        }
+    }
+    if len(Digits) != Decimals {
+        return "", fmt.Errorf("decimal part %q has %d digits instead of %d", Text, len(Digits), Decimals)
+    }
+    if Expected := GroupDecimalDigits(Digits, Style); Expected != Text {
+        return "", fmt.Errorf("decimal part %q does not follow the bracket layout, expected %q", Text, Expected)
+    }
+    return Digits, nil
+}
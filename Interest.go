@@ -0,0 +1,193 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "fmt"
+)
+
+//
+//	        Interest.go				Compound Interest and Rate Conversion Functions
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Interest Functions
+//			01  - CompoundInterest			Compounds a principal at a nominal rate, n times per year
+//			02  - ContinuousCompound		Compounds a principal continuously, using EXP
+//			03  - EffectiveRate			Returns the effective rate over a number of years
+//			04  - APRtoAPY				Converts a nominal annual rate into an annual percentage yield
+//			05  - APYtoAPR				Converts an annual percentage yield into a nominal annual rate
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Interest Functions
+//		Rates are fractions (0.05 for 5%), Years can have decimals.
+//		Computations are done with InterestGuardDigits decimals more than CurrencyPrecision
+//		and elastic integer precision, the results being truncated to CurrencyPrecision at the end,
+//		so they match the on-chain payouts instead of float64 approximations.
+//
+// ================================================
+//
+// InterestGuardDigits are the extra decimals used in the intermediate interest computations.
+const InterestGuardDigits = uint32(20)
+
+// ================================================
+//
+// # Function 01.01 - CompoundInterest
+//
+// CompoundInterest compounds the Principal at the nominal annual Rate, Frequency times per year,
+// for the given Years: Final = Principal * (1 + Rate/Frequency) ** (Frequency * Years).
+// It returns the Final amount and the Interest earned, both truncated to CurrencyPrecision.
+// 1000 at 5% compounded monthly for 10 years gives 1647.009497690283...
+func CompoundInterest(Principal, Rate, Years *p.Decimal, Frequency int64) (*p.Decimal, *p.Decimal, error) {
+    Growth, err := growthFactor(Rate, Years, Frequency)
+    if err != nil {
+        return nil, nil, fmt.Errorf("CompoundInterest: %w", err)
+    }
+    Final, Interest, err := interestResult(Principal, Growth)
+    if err != nil {
+        return nil, nil, fmt.Errorf("CompoundInterest: %w", err)
+    }
+    return Final, Interest, nil
+}
+
+// ================================================
+//
+// # Function 01.02 - ContinuousCompound
+//
+// ContinuousCompound compounds the Principal continuously at the annual Rate
+// for the given Years: Final = Principal * e ** (Rate * Years).
+// It returns the Final amount and the Interest earned, both truncated to CurrencyPrecision.
+func ContinuousCompound(Principal, Rate, Years *p.Decimal) (*p.Decimal, *p.Decimal, error) {
+    Growth, err := growthFactor(Rate, Years, 0)
+    if err != nil {
+        return nil, nil, fmt.Errorf("ContinuousCompound: %w", err)
+    }
+    Final, Interest, err := interestResult(Principal, Growth)
+    if err != nil {
+        return nil, nil, fmt.Errorf("ContinuousCompound: %w", err)
+    }
+    return Final, Interest, nil
+}
+
+// ================================================
+//
+// # Function 01.03 - EffectiveRate
+//
+// EffectiveRate returns the rate effectively earned over the given Years when the nominal annual
+// Rate is compounded Frequency times per year: (1 + Rate/Frequency) ** (Frequency * Years) - 1.
+// A zero Frequency means continuous compounding: e ** (Rate * Years) - 1.
+// The result is truncated to CurrencyPrecision.
+func EffectiveRate(Rate, Years *p.Decimal, Frequency int64) (*p.Decimal, error) {
+    Growth, err := growthFactor(Rate, Years, Frequency)
+    if err != nil {
+        return nil, fmt.Errorf("EffectiveRate: %w", err)
+    }
+    return TruncToCurrency(SUB(CurrencyPrecision+InterestGuardDigits, Growth, p.NFI(1))), nil
+}
+
+// ================================================
+//
+// # Function 01.04 - APRtoAPY
+//
+// APRtoAPY converts the nominal annual rate, compounded Frequency times per year,
+// into the annual percentage yield: (1 + APR/Frequency) ** Frequency - 1.
+// A zero Frequency means continuous compounding. 5% compounded daily (365) gives 0.051267...
+func APRtoAPY(APR *p.Decimal, Frequency int64) (*p.Decimal, error) {
+    APY, err := EffectiveRate(APR, p.NFI(1), Frequency)
+    if err != nil {
+        return nil, fmt.Errorf("APRtoAPY: %w", err)
+    }
+    return APY, nil
+}
+
+// ================================================
+//
+// # Function 01.05 - APYtoAPR
+//
+// APYtoAPR converts the annual percentage yield into the nominal annual rate
+// compounded Frequency times per year: Frequency * ((1 + APY) ** (1/Frequency) - 1).
+// A zero Frequency means continuous compounding: ln(1 + APY).
+// The result is truncated to CurrencyPrecision.
+func APYtoAPR(APY *p.Decimal, Frequency int64) (*p.Decimal, error) {
+    var (
+        Decimals = CurrencyPrecision + InterestGuardDigits
+        One      = p.NFI(1)
+        Growth   = ADD(Decimals, One, APY)
+    )
+    if Frequency < 0 {
+        return nil, fmt.Errorf("APYtoAPR: negative compounding frequency %d", Frequency)
+    }
+    if DecimalLessThanOrEqual(Growth, p.NFI(0)) == true {
+        return nil, fmt.Errorf("APYtoAPR: the yield %s must be greater than -1", APY.String())
+    }
+    if Frequency == 0 {
+        return MUL(CurrencyPrecision, LN(Decimals, Growth), One), nil
+    }
+    n := p.NFI(Frequency)
+    PeriodGrowth := EXP(Decimals, DIV(Decimals, LN(Decimals, Growth), n))
+    return MUL(CurrencyPrecision, n, SUB(Decimals, PeriodGrowth, One)), nil
+}
+
+// ================================================
+//
+// growthFactor returns (1 + Rate/Frequency) ** (Frequency * Years),
+// or e ** (Rate * Years) for a zero Frequency, with InterestGuardDigits extra decimals.
+// Whole numbers of periods are computed by repeated multiplication, each step being truncated.
+func growthFactor(Rate, Years *p.Decimal, Frequency int64) (*p.Decimal, error) {
+    Decimals := CurrencyPrecision + InterestGuardDigits
+    if Frequency < 0 {
+        return nil, fmt.Errorf("negative compounding frequency %d", Frequency)
+    }
+    if DecimalLessThan(Years, p.NFI(0)) == true {
+        return nil, fmt.Errorf("negative number of years %s", Years.String())
+    }
+    if Frequency == 0 {
+        return EXP(Decimals, MUL(Decimals, Rate, Years)), nil
+    }
+    
+    n := p.NFI(Frequency)
+    Base := ADD(Decimals, p.NFI(1), DIV(Decimals, Rate, n))
+    if DecimalLessThanOrEqual(Base, p.NFI(0)) == true {
+        return nil, fmt.Errorf("the rate %s is not greater than -%d", Rate.String(), Frequency)
+    }
+    Periods := MUL(Decimals, n, Years)
+    WholePeriods := MUL(0, Periods, p.NFI(1))
+    if DecimalEqual(WholePeriods, Periods) == true {
+        return powInt(Decimals, Base, p.INT64(WholePeriods)), nil
+    }
+    return EXP(Decimals, MUL(Decimals, Periods, LN(Decimals, Base))), nil
+}
+
+// ================================================
+//
+// interestResult applies the Growth factor to the Principal, returning
+// the Final amount and the Interest, truncated to CurrencyPrecision.
+func interestResult(Principal, Growth *p.Decimal) (*p.Decimal, *p.Decimal, error) {
+    if DecimalLessThan(Principal, p.NFI(0)) == true {
+        return nil, nil, fmt.Errorf("negative principal %s", Principal.String())
+    }
+    Final := MUL(CurrencyPrecision, Principal, Growth)
+    return Final, SUB(CurrencyPrecision, Final, TruncToCurrency(MUL(CurrencyPrecision, Principal, p.NFI(1)))), nil
+}
+
+// ================================================
+//
+// powInt returns Base ** Exponent for a non-negative integer Exponent, truncated to "Decimals" decimals.
+// Every multiplication is truncated, exponentiation by squaring keeping their number logarithmic;
+// with 0 decimals and an integer Base the result is exact.
+func powInt(Decimals uint32, Base *p.Decimal, Exponent int64) *p.Decimal {
+    Result, Square := p.NFI(1), Base
+    for k := Exponent; k > 0; k = k / 2 {
+        if k%2 == 1 {
+            Result = MUL(Decimals, Result, Square)
+        }
+        Square = MUL(Decimals, Square, Square)
+    }
+    return Result
+}
@@ -0,0 +1,274 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "strings"
+)
+
+//
+//	        JSONEncoding.go				JSON and Text Marshaling of Decimals and Amounts
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Wrapper Types
+//			01  - Decimal				Wrapper of *p.Decimal implementing the marshaling interfaces
+//			02  - AttoPlasmAmount			Amount marshaled as an integer number of AttoPlasms
+//		02 Decimal Marshaling
+//			01  - Decimal.MarshalText		Writes the decimal using DTS
+//			02  - Decimal.UnmarshalText		Parses a plain decimal strictly
+//			03  - Decimal.MarshalJSON		Writes the decimal as a JSON string
+//			04  - Decimal.UnmarshalJSON		Parses a JSON string or number strictly
+//		03 Amount Marshaling
+//			01  - Amount.MarshalJSON		Writes the Amount as a Koson decimal string
+//			02  - Amount.UnmarshalJSON		Parses a Koson decimal having at most CurrencyPrecision decimals
+//			03  - AttoPlasmAmount.MarshalJSON	Writes the Amount as an AttoPlasm integer string
+//			04  - AttoPlasmAmount.UnmarshalJSON	Parses an AttoPlasm integer
+//		04 Strict Parsing
+//			01  - ParsePlainDecimal			Parses -ddd.ddd text, rejecting exponents, NaN and Infinity
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Wrapper Types
+//		Values are written as JSON strings, so that REST payloads never lose precision through float64.
+//		Reading accepts JSON strings and JSON numbers, both being parsed from their text with
+//		ParsePlainDecimal: exponents, NaN, Infinity and too many decimals are rejected.
+//		A JSON null leaves the value unchanged, like encoding/json does.
+//
+// ================================================
+//
+// MaxJSONDecimals is the maximum number of decimals accepted when unmarshaling a Decimal.
+const MaxJSONDecimals = MaxMathPrecision
+
+// ================================================
+//
+// # Type 01.01 - Decimal
+//
+// Decimal wraps a *p.Decimal, implementing encoding.TextMarshaler, encoding.TextUnmarshaler,
+// json.Marshaler and json.Unmarshaler. A nil Number is marshaled as null.
+type Decimal struct {
+    Number *p.Decimal
+}
+
+// ================================================
+//
+// # Type 01.02 - AttoPlasmAmount
+//
+// AttoPlasmAmount is an Amount written in JSON as an integer number of AttoPlasms,
+// for instance "1500000000000000000" instead of the "1.500000000000000000" used by Amount.
+type AttoPlasmAmount struct {
+    Amount
+}
+
+// ================================================================================================
+//
+//	02 Decimal Marshaling
+//
+// ================================================
+//
+// # Function 02.01 - Decimal.MarshalText
+//
+// MarshalText writes the decimal using DTS, for instance -1234.50
+func (d Decimal) MarshalText() ([]byte, error) {
+    if d.Number == nil {
+        return nil, fmt.Errorf("Decimal.MarshalText: nil decimal")
+    }
+    if d.Number.Form != p.Finite {
+        return nil, fmt.Errorf("Decimal.MarshalText: %s is not a finite decimal", d.Number.String())
+    }
+    return []byte(DTS(d.Number)), nil
+}
+
+// ================================================
+//
+// # Function 02.02 - Decimal.UnmarshalText
+//
+// UnmarshalText parses the text with ParsePlainDecimal, allowing MaxJSONDecimals decimals.
+func (d *Decimal) UnmarshalText(Text []byte) error {
+    Number, err := ParsePlainDecimal(string(Text), MaxJSONDecimals)
+    if err != nil {
+        return fmt.Errorf("Decimal.UnmarshalText: %w", err)
+    }
+    d.Number = Number
+    return nil
+}
+
+// ================================================
+//
+// # Function 02.03 - Decimal.MarshalJSON
+//
+// MarshalJSON writes the decimal as a JSON string, for instance "-1234.50"
+func (d Decimal) MarshalJSON() ([]byte, error) {
+    if d.Number == nil {
+        return []byte("null"), nil
+    }
+    Text, err := d.MarshalText()
+    if err != nil {
+        return nil, err
+    }
+    return json.Marshal(string(Text))
+}
+
+// ================================================
+//
+// # Function 02.04 - Decimal.UnmarshalJSON
+//
+// UnmarshalJSON parses a JSON string or number, allowing MaxJSONDecimals decimals.
+func (d *Decimal) UnmarshalJSON(Data []byte) error {
+    Text, IsNull, err := jsonNumberText(Data)
+    if err != nil {
+        return fmt.Errorf("Decimal.UnmarshalJSON: %w", err)
+    }
+    if IsNull == true {
+        return nil
+    }
+    Number, err := ParsePlainDecimal(Text, MaxJSONDecimals)
+    if err != nil {
+        return fmt.Errorf("Decimal.UnmarshalJSON: %w", err)
+    }
+    d.Number = Number
+    return nil
+}
+
+// ================================================================================================
+//
+//	03 Amount Marshaling
+//
+// ================================================
+//
+// # Function 03.01 - Amount.MarshalJSON
+//
+// MarshalJSON writes the Amount as a Koson decimal string having CurrencyPrecision decimals,
+// for instance "1.500000000000000000"
+func (a Amount) MarshalJSON() ([]byte, error) {
+    return json.Marshal(DTS(a.Koson()))
+}
+
+// ================================================
+//
+// # Function 03.02 - Amount.UnmarshalJSON
+//
+// UnmarshalJSON parses a Koson decimal string or number, having at most CurrencyPrecision decimals.
+func (a *Amount) UnmarshalJSON(Data []byte) error {
+    Text, IsNull, err := jsonNumberText(Data)
+    if err != nil {
+        return fmt.Errorf("Amount.UnmarshalJSON: %w", err)
+    }
+    if IsNull == true {
+        return nil
+    }
+    Number, err := ParsePlainDecimal(Text, CurrencyPrecision)
+    if err != nil {
+        return fmt.Errorf("Amount.UnmarshalJSON: %w", err)
+    }
+    Value, err := AmountFromKoson(Number)
+    if err != nil {
+        return fmt.Errorf("Amount.UnmarshalJSON: %w", err)
+    }
+    *a = Value
+    return nil
+}
+
+// ================================================
+//
+// # Function 03.03 - AttoPlasmAmount.MarshalJSON
+//
+// MarshalJSON writes the Amount as an integer string of AttoPlasms, for instance "1500000000000000000"
+func (a AttoPlasmAmount) MarshalJSON() ([]byte, error) {
+    return json.Marshal(DTS(a.AttoPlasms()))
+}
+
+// ================================================
+//
+// # Function 03.04 - AttoPlasmAmount.UnmarshalJSON
+//
+// UnmarshalJSON parses an integer number of AttoPlasms, given as a JSON string or number.
+func (a *AttoPlasmAmount) UnmarshalJSON(Data []byte) error {
+    Text, IsNull, err := jsonNumberText(Data)
+    if err != nil {
+        return fmt.Errorf("AttoPlasmAmount.UnmarshalJSON: %w", err)
+    }
+    if IsNull == true {
+        return nil
+    }
+    Number, err := ParsePlainDecimal(Text, 0)
+    if err != nil {
+        return fmt.Errorf("AttoPlasmAmount.UnmarshalJSON: %w", err)
+    }
+    Value, err := AmountFromAttoPlasms(Number)
+    if err != nil {
+        return fmt.Errorf("AttoPlasmAmount.UnmarshalJSON: %w", err)
+    }
+    a.Amount = Value
+    return nil
+}
+
+// ================================================================================================
+//
+//	04 Strict Parsing
+//
+// ================================================
+//
+// # Function 04.01 - ParsePlainDecimal
+//
+// ParsePlainDecimal parses text of the form -ddd.ddd, the sign and the decimals being optional.
+// Leading zeros (007), exponents (1E+3), NaN, Infinity, a "+" sign, a missing integer part (.5),
+// a dangling point (5.) and more than MaxDecimals decimals are all rejected.
+// Trailing zeros are kept: "1.50" has two decimals.
+func ParsePlainDecimal(Text string, MaxDecimals uint32) (*p.Decimal, error) {
+    Body := strings.TrimPrefix(Text, "-")
+    IntegerText, DecimalText, HasPoint := strings.Cut(Body, ".")
+    switch {
+    case IntegerText == "" || onlyDigits(IntegerText) == false:
+        return nil, fmt.Errorf("%q is not a plain decimal", Text)
+    case HasPoint == true && (DecimalText == "" || onlyDigits(DecimalText) == false):
+        return nil, fmt.Errorf("%q is not a plain decimal", Text)
+    case len(IntegerText) > 1 && IntegerText[0] == '0':
+        return nil, fmt.Errorf("%q has leading zeros", Text)
+    case len(DecimalText) > int(MaxDecimals):
+        return nil, fmt.Errorf("%q has more than %d decimals", Text, MaxDecimals)
+    }
+    Number := p.NFS(Text)
+    if Number.IsZero() == true {
+        Number.Negative = false
+    }
+    return Number, nil
+}
+
+// ================================================
+//
+// onlyDigits returns true if the text is made only of the digits 0 to 9.
+func onlyDigits(Text string) bool {
+    for i := 0; i < len(Text); i++ {
+        if Text[i] < '0' || Text[i] > '9' {
+            return false
+        }
+    }
+    return true
+}
+
+// ================================================
+//
+// jsonNumberText returns the text of a JSON string or number, reporting a JSON null.
+func jsonNumberText(Data []byte) (string, bool, error) {
+    Data = bytes.TrimSpace(Data)
+    if string(Data) == "null" {
+        return "", true, nil
+    }
+    if len(Data) > 0 && Data[0] == '"' {
+        var Text string
+        if err := json.Unmarshal(Data, &Text); err != nil {
+            return "", false, err
+        }
+        return Text, false, nil
+    }
+    return string(Data), false, nil
+}
@@ -4,7 +4,7 @@ import (
     p "Firefly-APD"
     "fmt"
     "os"
-    "strconv"
+    "strings"
 )
 
 const (
@@ -79,6 +79,7 @@ var (
 //			01  - MULx				Multiplies 2 numbers with custom total precision
 //			02  - MULs				Multiplies 2 numbers within CryptoplasmPrecisionContext (70 total precision)
 //			03  - MULxc				Multiplies 2 numbers with elastic integer precision and 100 max decimal precision
+//			03a - MUL				Multiplies 2 numbers with custom decimal precision and elastic integer precision
 //			04  - PRDx				Multiplies multiple numbers within a specific precision context
 //			05  - PRDs				Multiplies multiple numbers within CryptoplasmPrecisionContext
 //			06  - PRDxc				Multiplies multiple numbers with elastic integer precision and 100 max decimal precision
@@ -87,10 +88,13 @@ var (
 //			09  - POWxcs				Computes x ** y with elastic integer precision and custom max decimal precision
 //			10  - POWxc				Computes x ** y with elastic integer precision and 150 max decimal precision
 //			11  - Logarithm				Computes the logarithm from "number" in base "base".
+//			12  - EXP				Computes e ** x with custom decimal precision and elastic integer precision
+//			13  - LN				Computes the natural logarithm with custom decimal precision
 //		05 Division Functions
 //			01  - DIVx				Divides 2 numbers within a specific precision context
 //			02  - DIVs				Divides 2 numbers within CryptoplasmPrecisionContext
 //			03  - DIVxc				Divides 2 numbers with elastic integer precision and 100/101 max decimal precision
+//			03a - DIV				Divides 2 numbers with custom decimal precision and elastic integer precision
 //			04  - DivInt				Returns x // y, uses elastic Precision (result is "integer")
 //			05  - DivMod				Returns x % y, uses elastic Precision (result is the rest)
 //	 05a Mean Functions
@@ -115,6 +119,7 @@ var (
 //			01  - Convert2AU			Converts Koson Amount to AtomicUnits (AttoPlasms)
 //			02  - AttoPlasm2String			Converts AttoPlasms into a slice of strings
 //			03  - KosonicDecimalConversion		Converts a Koson Amount into a string that can be better used for display purposes
+//			04  - Block2Print			Converts a BlockHeight into a string that can be better used for display purposes
 //
 // ================================================================================================
 // ************************************************************************************************
@@ -732,6 +737,39 @@ func MULxc(member1, member2 *p.Decimal) *p.Decimal {
     return result
 }
 
+// ================================================
+//
+// # Function 04.03a - MUL
+//
+// MUL multiplies two decimals within custom Precision modified CryptoplasmPrecisionContext Context
+// The Precision has "DecimalPrecision" decimal Precision plus elastic integer Precision.
+// The integer Precision is computed from the adjusted exponents of the two members.
+// The Result is truncated to "DecimalPrecision" decimals.
+func MUL(DecimalPrecision uint32, member1, member2 *p.Decimal) *p.Decimal {
+    var (
+        result           = new(p.Decimal)
+        IntegerPrecision uint32
+    )
+    
+    AdjustedMember1 := member1.NumDigits() + int64(member1.Exponent) //Digits before the coma
+    AdjustedMember2 := member2.NumDigits() + int64(member2.Exponent) //Digits before the coma
+    if AdjustedMember1+AdjustedMember2 > 0 {
+        IntegerPrecision = uint32(AdjustedMember1 + AdjustedMember2)
+    }
+    
+    MultiplicationPrecision := IntegerPrecision + DecimalPrecision + 1
+    cc := c.WithPrecision(MultiplicationPrecision)
+    _, _ = cc.Mul(result, member1, member2)
+    
+    //Positive Exponents are brought back to zero, so that the truncation can take place.
+    if result.Exponent > 0 {
+        cq := c.WithPrecision(uint32(result.NumDigits()) + uint32(result.Exponent))
+        _, _ = cq.Quantize(result, result, 0)
+    }
+    result = TruncateCustom(result, DecimalPrecision)
+    return result
+}
+
 // ================================================
 //
 // # Function 04.04 - PRDx
@@ -901,6 +939,47 @@ func Logarithm(base, number *p.Decimal) *p.Decimal {
     return CustomLog
 }
 
+// ================================================
+//
+// # Function 04.12 - EXP
+//
+// EXP computes e ** x within custom Precision modified CryptoplasmPrecisionContext Context
+// The Precision has "DecimalPrecision" decimal Precision plus elastic integer Precision,
+// e ** x having about x / ln(10) integer digits.
+// The Result is truncated to "DecimalPrecision" decimals.
+func EXP(DecimalPrecision uint32, member *p.Decimal) *p.Decimal {
+    var (
+        result           = new(p.Decimal)
+        IntegerPrecision uint32
+    )
+    
+    if member.Negative == false {
+        IntegerPrecision = uint32(p.INT64(DIV(0, member, p.NFS("2.302585")))) + 1
+    }
+    cc := c.WithPrecision(IntegerPrecision + DecimalPrecision + 2)
+    _, _ = cc.Exp(result, member)
+    return MUL(DecimalPrecision, result, p.NFI(1))
+}
+
+// ================================================
+//
+// # Function 04.13 - LN
+//
+// LN computes the natural logarithm of x within custom Precision modified CryptoplasmPrecisionContext Context
+// The integer Precision is given by the number of digits of x (ln(10^n) = 2.30 * n).
+// The Result is truncated to "DecimalPrecision" decimals.
+// x must be positive: for zero or negative values no error is reported, the Result being NaN,
+// so callers must check the input beforehand.
+func LN(DecimalPrecision uint32, member *p.Decimal) *p.Decimal {
+    var result = new(p.Decimal)
+    
+    AdjustedMember := member.NumDigits() + int64(member.Exponent) //Digits before the coma
+    IntegerPrecision := uint32(len(fmt.Sprint(AdjustedMember))) + 1
+    cc := c.WithPrecision(IntegerPrecision + DecimalPrecision + 2)
+    _, _ = cc.Ln(result, member)
+    return MUL(DecimalPrecision, result, p.NFI(1))
+}
+
 // ================================================
 //
 // # Function 05.01 - DIVx
@@ -1016,6 +1095,40 @@ func DIVxc(member1, member2 *p.Decimal) *p.Decimal {
     return result
 }
 
+// ================================================
+//
+// # Function 05.03a - DIV
+//
+// DIV divides two decimals within custom Precision modified CryptoplasmPrecisionContext Context
+// The Precision has "DecimalPrecision" decimal Precision plus elastic integer Precision.
+// The integer Precision is computed from the adjusted exponents of the two members,
+// so that numbers having a positive exponent (like 1E+3) are also handled.
+// The Result is truncated to "DecimalPrecision" decimals.
+func DIV(DecimalPrecision uint32, member1, member2 *p.Decimal) *p.Decimal {
+    var (
+        result           = new(p.Decimal)
+        IntegerPrecision uint32
+    )
+    
+    AdjustedMember1 := member1.NumDigits() + int64(member1.Exponent) //Digits before the coma
+    AdjustedMember2 := member2.NumDigits() + int64(member2.Exponent) //Digits before the coma
+    if AdjustedMember1-AdjustedMember2+1 > 0 {
+        IntegerPrecision = uint32(AdjustedMember1 - AdjustedMember2 + 1)
+    }
+    
+    TotalDivisionPrecision := IntegerPrecision + DecimalPrecision + 1
+    result = DIVx(TotalDivisionPrecision, member1, member2)
+    
+    //Quo adjusts to the ideal Exponent, which can be positive (100/0.1 = 1.00E+3)
+    //The Exponent is brought back to zero, so that the truncation can take place.
+    if result.Exponent > 0 {
+        cc := c.WithPrecision(uint32(result.NumDigits()) + uint32(result.Exponent))
+        _, _ = cc.Quantize(result, result, 0)
+    }
+    result = TruncateCustom(result, DecimalPrecision)
+    return result
+}
+
 // ================================================
 //
 // # Function 05.04 - DivInt
@@ -1104,8 +1217,9 @@ func TruncSeed(SeedNumber *p.Decimal) *p.Decimal {
 // TruncToCurrency truncates the decimal to CurrencyPrecision
 // Currency Precision is currently set to 18 Decimals
 // It is Context Precision Independent
+// Tokens with other decimals use the Truncate method of their TokenProfile.
 func TruncToCurrency(Amount2BecomeCurrency *p.Decimal) *p.Decimal {
-    return TruncateCustom(Amount2BecomeCurrency, CurrencyPrecision)
+    return CryptoPlasmProfile.Truncate(Amount2BecomeCurrency)
 }
 
 // ================================================
@@ -1186,6 +1300,7 @@ func Reverse(a []*p.Decimal) []*p.Decimal {
 //
 // PrintStringList short for PrintDecimalList, prints the decimals
 // within the given list/slice
+// FprintDecimalList writes them to any io.Writer, returning errors.
 func PrintDecimalList(a []*p.Decimal) {
     for i := 0; i < len(a); i++ {
         fmt.Println("Element is,", a[i])
@@ -1198,6 +1313,7 @@ func PrintDecimalList(a []*p.Decimal) {
 //
 // WriteList writes the strings from the slice to an external file
 // as Name can be used "File.txt" as the output file.
+// FwriteList and WriteListFile return the errors instead of printing them.
 func WriteList(Name string, List []string) {
     f, err := os.Create(Name)
     
@@ -1256,9 +1372,21 @@ func Count4Coma(Number *p.Decimal) int64 {
 //
 // DTS Converts Decimal to String with "." as Separator
 // Similar to .String() function, but you can choose separator.
+// Negative numbers are prefixed with "-", positive exponents (1E+3)
+// are written out as integers (1000), so the output never uses the exponent form.
 func DTS(Input *p.Decimal) (Output string) {
     var Zeros string
     
+    if Input.Negative == true && Input.IsZero() == false {
+        return "-" + DTS(new(p.Decimal).Abs(Input))
+    }
+    if Input.Exponent > 0 {
+        if Input.IsZero() == true {
+            return "0"
+        }
+        return Input.Coeff.Text(10) + strings.Repeat("0", int(Input.Exponent))
+    }
+    
     //Function makes a rune chain from a text string
     MakeRuneChain := func(Text string) []rune {
         Result := []rune(Text)
@@ -1330,13 +1458,9 @@ func DTS(Input *p.Decimal) (Output string) {
 // # Function 09.01 - CPConvert2AU
 //
 // Convert2AU converts a CryptoCurrency amount into Atomic Units
+// Tokens with other decimals use the Convert2AU method of their TokenProfile.
 func Convert2AU(cpAmount *p.Decimal) *p.Decimal {
-    tcpAmount := TruncToCurrency(cpAmount)
-    NumberDigits := Count4Coma(cpAmount)
-    IP := uint32(NumberDigits) + CurrencyPrecision
-    AU := MULx(IP, tcpAmount, AUs)
-    
-    return AU
+    return CryptoPlasmProfile.Convert2AU(cpAmount)
 }
 
 // ================================================
@@ -1344,29 +1468,11 @@ func Convert2AU(cpAmount *p.Decimal) *p.Decimal {
 // # Function 09.02 - AttoPlasm2String
 //
 // AttoPlasm2String converts a CryptoPlasm AUs (AttoPlasms)
-// into a slice of strings
+// into a slice of strings, one for each digit.
+// Use the AtomicUnits2String method of a TokenProfile
+// to obtain the digits padded to the token decimals.
 func AttoPlasm2String(Number *p.Decimal) []string {
-    var SliceStr []string
-    Ten := p.NFI(10)
-    AuDigits := Number.NumDigits()
-    Exp := AuDigits - 1
-    IP := uint32(AuDigits)
-    //Exp := p.NFI(NumberDigitsAU - 1)
-    ToSequence := Number
-    for i := Exp; i >= 0; i-- {
-        idec := p.NFI(i)
-        Power := POWx(IP, Ten, idec)
-        Division := DIVx(IP, ToSequence, Power)
-        DigitIs := TruncateCustom(Division, 0)
-        DI := p.INT64(DigitIs)
-        DigitIsString := strconv.Itoa(int(DI))
-        SliceStr = append(SliceStr, DigitIsString)
-        
-        Rest := SUBx(IP, Division, DigitIs)
-        SmallAU := MULx(IP, Rest, Power)
-        ToSequence = SmallAU
-    }
-    return SliceStr
+    return atomicDigits(Number)
 }
 
 // ================================================
@@ -1374,164 +1480,26 @@ func AttoPlasm2String(Number *p.Decimal) []string {
 // # Function 09.03 - KosonicDecimalConversion
 //
 // KosonicDecimalConversion converts CryptoPlasm amount into a string
-// to be used for printing purposes.
-// For now only a "." as decimal character is implemented.
-// Different Schemas can be added (for instance using coma<,> as decimal separator
-// instead of point<.>; Or using points for thousand separator,
-// or even separating at 2 position for Lakhs and Crores.
-// Converts 123,432564123546789786 to 123.[432|564|123][546|789|786]
+// to be used for printing purposes, using the KosonicSchema.
+// Coma<,> is used as decimal separator and points<.> as thousand separators,
+// the 18 decimals being bracketed in groups of 3.
+// Other Schemas (like SchemaDeDE or SchemaEnIN for Lakhs and Crores)
+// can be used with the FormatCurrency function, while tokens with
+// other decimals use the Format method of their TokenProfile.
+// Converts 123,432564123546789786 to 123,[432|564|123][546|789|786]
 func KosonicDecimalConversion(cpAmount *p.Decimal) string {
-    var (
-        StringResult  string
-        ComaPosition  int64
-        PointPosition int64
-        DigitTier     int64
-    )
-    
-    //String Variable
-    DecimalSeparator := ","
-    ThousandSeparator := "."
-    InsertFront := "["
-    InsertMiddle := "|"
-    InsertEnd := "]"
-    
-    if DecimalEqual(cpAmount, p.NFI(0)) == true {
-        StringResult = "0,[000|000|000][000|000|000]"
-    } else {
-        Prec := int64(CurrencyPrecision)
-        AU := Convert2AU(cpAmount)
-        SliceStr := AttoPlasm2String(AU)
-        NumberDigits := Count4Coma(AU)
-        
-        InsertString := func(a []string, index int64, value string) []string {
-            if int64(len(a)) == index { // nil or empty slice or after last element
-                return append(a, value)
-            }
-            a = append(a[:index+1], a[index:]...) // index < len(a)
-            a[index] = value
-            return a
-        }
-        
-        //Computing the Decimal Separator position
-        if NumberDigits <= (Prec + 1) {
-            ComaPosition = 1
-        } else {
-            ComaPosition = NumberDigits - Prec
-        }
-        //Inserting the Decimal Separator
-        SliceStr = InsertString(SliceStr, ComaPosition, DecimalSeparator)
-        
-        //Computing the 1000 Separator positions
-        Difference := NumberDigits - (Prec + 1)
-        if Difference%3 == 0 {
-            DigitTier = 1
-        } else if Difference%3 == 1 {
-            DigitTier = 2
-        } else if Difference%3 == 2 {
-            DigitTier = 3
-        }
-        TSNumber := (NumberDigits - (Prec + 1)) / 3
-        
-        //Adding the 1000 Separator as points
-        for i := int64(1); i <= TSNumber; i++ {
-            PointPosition = (i-1)*4 + DigitTier
-            SliceStr = InsertString(SliceStr, PointPosition, ThousandSeparator)
-        }
-        
-        //fmt.Println("new slice is", SliceStr)
-        //fmt.Println("Slice Str cu virgula si 1000 separator este", len(SliceStr))
-        
-        //Adding Decimal Separators
-        SliceStr = InsertString(SliceStr, int64(len(SliceStr)), InsertEnd)
-        SliceStr = InsertString(SliceStr, int64(len(SliceStr)-4), InsertMiddle)
-        SliceStr = InsertString(SliceStr, int64(len(SliceStr)-8), InsertMiddle)
-        SliceStr = InsertString(SliceStr, int64(len(SliceStr)-12), InsertFront)
-        SliceStr = InsertString(SliceStr, int64(len(SliceStr)-13), InsertEnd)
-        SliceStr = InsertString(SliceStr, int64(len(SliceStr)-17), InsertMiddle)
-        SliceStr = InsertString(SliceStr, int64(len(SliceStr)-21), InsertMiddle)
-        SliceStr = InsertString(SliceStr, int64(len(SliceStr)-25), InsertFront)
-        
-        //Removing "0," from the SliceString, displaying only Decimals, in case os subunitary values.
-        if len(SliceStr) == 28 && SliceStr[0] == "0" {
-            SliceStr = SliceStr[2:]
-        }
-        
-        //Converting Slice to string
-        for i := 0; i < len(SliceStr); i++ {
-            StringResult = StringResult + SliceStr[i]
-        }
-    }
-    
-    return StringResult
+    return CryptoPlasmProfile.Format(cpAmount)
 }
 
 // ================================================
 //
-// # Function 09.03 - XPAmountConv2Print
+// # Function 09.04 - Block2Print
 //
-// XPAmountConv2Print converts the BlockHeight decimal into a string
-// to be used for printing purposes. A "." is inserted ever 1000.
-// For now only a "." as decimal character is implemented.
-// Different Schemas can be added (for instance using coma<,> as decimal separator
-// instead of point<.>; Or using points for thousand separator,
-// or even separating at 2 position for Lakhs and Crores.
+// Block2Print converts the BlockHeight decimal into a string
+// to be used for printing purposes, using the KosonicSchema.
+// A "." is inserted every 1000 and the number is enclosed in brackets.
+// Other Schemas can be used with the FormatBlock function.
 // A number of 3215432 is converted to [3.215.432]
 func Block2Print(MKSP *p.Decimal) string {
-    var (
-        StringResult  string
-        DigitTier     int64
-        PointPosition int64
-    )
-    
-    //String Variable
-    //DecimalSeparator := ","
-    ThousandSeparator := "."
-    InsertFront := "["
-    //InsertMiddle := "|"
-    InsertEnd := "]"
-    
-    if DecimalEqual(MKSP, p.NFI(0)) == true {
-        StringResult = InsertFront + "ZERO" + InsertEnd
-    } else {
-        //InsertString Function
-        InsertString := func(a []string, index int64, value string) []string {
-            if int64(len(a)) == index { // nil or empty slice or after last element
-                return append(a, value)
-            }
-            a = append(a[:index+1], a[index:]...) // index < len(a)
-            a[index] = value
-            return a
-        }
-        
-        NumberDigits := Count4Coma(MKSP)
-        SliceStr := AttoPlasm2String(MKSP)
-        
-        //Computing the 1000 Separator positions
-        Difference := NumberDigits - 1
-        if Difference%3 == 0 {
-            DigitTier = 1
-        } else if Difference%3 == 1 {
-            DigitTier = 2
-        } else if Difference%3 == 2 {
-            DigitTier = 3
-        }
-        TSNumber := (NumberDigits - 1) / 3
-        
-        //Adding the 1000 Separator as points
-        for i := int64(1); i <= TSNumber; i++ {
-            PointPosition = (i-1)*4 + DigitTier
-            SliceStr = InsertString(SliceStr, PointPosition, ThousandSeparator)
-        }
-        
-        //Inserting Starting and Ending Brackets
-        SliceStr = InsertString(SliceStr, int64(len(SliceStr)), InsertEnd)
-        SliceStr = InsertString(SliceStr, int64(0), InsertFront)
-        
-        //Converting Slice to string
-        for i := 0; i < len(SliceStr); i++ {
-            StringResult = StringResult + SliceStr[i]
-        }
-    }
-    
-    return StringResult
+    return FormatBlock(MKSP, KosonicSchema)
 }
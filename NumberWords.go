@@ -0,0 +1,340 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "fmt"
+    "strconv"
+    "strings"
+)
+
+//
+//	        NumberWords.go				Spelling out Amounts in Words
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Language Type
+//			01  - Language				Language used for spelling out numbers
+//		02 Spelling Functions
+//			01  - IntegerToWords			Spells out the integer part of a decimal
+//			02  - NumberToWords			Spells out a CryptoPlasm amount, decimals as a fraction
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Language Type
+//
+// ================================================
+//
+// # Type 01.01 - Language
+//
+// Language selects the language used for spelling out numbers.
+type Language int
+
+const (
+    English  Language = iota // one hundred twenty-three Koson and forty-three hundredths
+    Romanian                 // o sută douăzeci și trei de Koson și patruzeci și trei de sutimi
+)
+
+// CurrencyName is the name of the currency unit used when spelling out amounts.
+const CurrencyName = "Koson"
+
+// Romanian grammatical genders, the numerals 1 and 2 being the ones that change.
+const (
+    roMasculine = iota
+    roFeminine
+    roNeuter
+)
+
+var (
+    enUnits = []string{"", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+        "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+    enTens   = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+    enScales = []string{"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion",
+        "sextillion", "septillion", "octillion", "nonillion", "decillion"}
+    
+    roUnits = []string{"", "unu", "doi", "trei", "patru", "cinci", "șase", "șapte", "opt", "nouă", "zece",
+        "unsprezece", "doisprezece", "treisprezece", "paisprezece", "cincisprezece", "șaisprezece",
+        "șaptesprezece", "optsprezece", "nouăsprezece"}
+    roTens = []string{"", "", "douăzeci", "treizeci", "patruzeci", "cincizeci", "șaizeci", "șaptezeci",
+        "optzeci", "nouăzeci"}
+    //Long scale, singular and plural forms
+    roScales = [][2]string{{"", ""}, {"mie", "mii"}, {"milion", "milioane"}, {"miliard", "miliarde"},
+        {"bilion", "bilioane"}, {"biliard", "biliarde"}, {"trilion", "trilioane"}, {"triliard", "triliarde"},
+        {"cvadrilion", "cvadrilioane"}, {"cvadriliard", "cvadriliarde"}, {"cvintilion", "cvintilioane"},
+        {"cvintiliard", "cvintiliarde"}}
+    //Fraction denominators for 10^3, 10^6 ... 10^18, singular and plural forms
+    roOrdinals = [][2]string{{"", ""}, {"miime", "miimi"}, {"milionime", "milionimi"}, {"miliardime", "miliardimi"},
+        {"bilionime", "bilionimi"}, {"biliardime", "biliardimi"}, {"trilionime", "trilionimi"}}
+)
+
+// ================================================================================================
+//
+//	02 Spelling Functions
+//
+// ================================================
+//
+// # Function 02.01 - IntegerToWords
+//
+// IntegerToWords spells out the integer part of the decimal (its decimals are ignored),
+// for instance 1234 becomes "one thousand two hundred thirty-four" in English
+// and "o mie două sute treizeci și patru" in Romanian.
+// Numbers up to 10^36 - 1 are supported.
+func IntegerToWords(Number *p.Decimal, Lang Language) (string, error) {
+    Digits, _ := amountDigits(Number, 0)
+    Words, err := spellInteger(Digits, Lang, roMasculine)
+    if err != nil {
+        return "", fmt.Errorf("IntegerToWords: %w", err)
+    }
+    if Number.Negative == true && Digits != "0" {
+        Words = "minus " + Words
+    }
+    return Words, nil
+}
+
+// ================================================
+//
+// # Function 02.02 - NumberToWords
+//
+// NumberToWords spells out a CryptoPlasm amount, truncated to CurrencyPrecision like
+// TruncToCurrency does. The decimals are spelled out as a fraction, trailing zeros removed:
+// 123.43 becomes "one hundred twenty-three Koson and forty-three hundredths" in English
+// and "o sută douăzeci și trei de Koson și patruzeci și trei de sutimi" in Romanian.
+func NumberToWords(Number *p.Decimal, Lang Language) (string, error) {
+    var Words string
+    
+    IntegerDigits, DecimalDigits := amountDigits(Number, CurrencyPrecision)
+    DecimalDigits = strings.TrimRight(DecimalDigits, "0")
+    
+    Integer, err := spellInteger(IntegerDigits, Lang, roMasculine)
+    if err != nil {
+        return "", fmt.Errorf("NumberToWords: %w", err)
+    }
+    switch Lang {
+    case Romanian:
+        Words = roCounted(IntegerDigits, Integer, "un", CurrencyName, CurrencyName)
+    default:
+        Words = Integer + " " + CurrencyName
+    }
+    
+    if DecimalDigits != "" {
+        Fraction, err := spellFraction(DecimalDigits, Lang)
+        if err != nil {
+            return "", fmt.Errorf("NumberToWords: %w", err)
+        }
+        switch Lang {
+        case Romanian:
+            Words = Words + " și " + Fraction
+        default:
+            Words = Words + " and " + Fraction
+        }
+    }
+    if Number.Negative == true && (IntegerDigits != "0" || DecimalDigits != "") {
+        Words = "minus " + Words
+    }
+    return Words, nil
+}
+
+// ================================================
+//
+// amountDigits returns the integer and decimal digits of the absolute value
+// of the decimal, truncated to "Decimals" decimals.
+func amountDigits(Number *p.Decimal, Decimals uint32) (string, string) {
+    Truncated := MUL(Decimals, new(p.Decimal).Abs(Number), p.NFI(1))
+    Digits := Truncated.Coeff.Text(10)
+    if len(Digits) <= int(Decimals) {
+        Digits = strings.Repeat("0", int(Decimals)-len(Digits)+1) + Digits
+    }
+    return Digits[:len(Digits)-int(Decimals)], Digits[len(Digits)-int(Decimals):]
+}
+
+// ================================================
+//
+// spellInteger spells out a string of integer digits. For Romanian, Gender
+// is the gender of the noun counted by the last group of 3 digits.
+func spellInteger(Digits string, Lang Language, Gender int) (string, error) {
+    Digits = strings.TrimLeft(Digits, "0")
+    if Digits == "" {
+        return "zero", nil
+    }
+    if (len(Digits)+2)/3 > len(enScales) {
+        return "", fmt.Errorf("%d digits numbers are too large to be spelled out", len(Digits))
+    }
+    
+    //Splitting into groups of 3 digits, the most significant group first
+    var Groups []int
+    for End := len(Digits); End > 0; End -= 3 {
+        Start := End - 3
+        if Start < 0 {
+            Start = 0
+        }
+        Group, _ := strconv.Atoi(Digits[Start:End])
+        Groups = append([]int{Group}, Groups...)
+    }
+    
+    var Words []string
+    for i, Group := range Groups {
+        Scale := len(Groups) - 1 - i
+        if Group == 0 {
+            continue
+        }
+        switch Lang {
+        case Romanian:
+            switch {
+            case Scale == 0:
+                Words = append(Words, roBelowThousand(Group, Gender))
+            case Scale == 1 && Group == 1:
+                Words = append(Words, "o mie")
+            case Scale == 1:
+                Words = append(Words, roCounted(strconv.Itoa(Group), roBelowThousand(Group, roFeminine), "", "", roScales[1][1]))
+            case Group == 1:
+                Words = append(Words, "un "+roScales[Scale][0])
+            default:
+                Words = append(Words, roCounted(strconv.Itoa(Group), roBelowThousand(Group, roNeuter), "", "", roScales[Scale][1]))
+            }
+        default:
+            Words = append(Words, enBelowThousand(Group))
+            if Scale > 0 {
+                Words = append(Words, enScales[Scale])
+            }
+        }
+    }
+    return strings.Join(Words, " "), nil
+}
+
+// ================================================
+//
+// spellFraction spells out the decimal digits as a fraction over 10^len(Digits).
+func spellFraction(Digits string, Lang Language) (string, error) {
+    Numerator := strings.TrimLeft(Digits, "0")
+    Power := len(Digits)
+    Scale, Rest := Power/3, Power%3
+    
+    switch Lang {
+    case Romanian:
+        Words, err := spellInteger(Numerator, Lang, roFeminine)
+        if err != nil {
+            return "", err
+        }
+        Singular, Plural := "", ""
+        switch {
+        case Rest == 0:
+            Singular, Plural = roOrdinals[Scale][0], roOrdinals[Scale][1]
+        case Scale == 0 && Rest == 1:
+            Singular, Plural = "zecime", "zecimi"
+        case Scale == 0 && Rest == 2:
+            Singular, Plural = "sutime", "sutimi"
+        case Rest == 1:
+            Singular, Plural = "zecime de "+roOrdinals[Scale][0], "zecimi de "+roOrdinals[Scale][0]
+        default:
+            Singular, Plural = "sutime de "+roOrdinals[Scale][0], "sutimi de "+roOrdinals[Scale][0]
+        }
+        return roCounted(Numerator, Words, "o", Singular, Plural), nil
+    default:
+        Words, err := spellInteger(Numerator, Lang, roMasculine)
+        if err != nil {
+            return "", err
+        }
+        Ordinal := ""
+        switch {
+        case Scale == 0 && Rest == 1:
+            Ordinal = "tenth"
+        case Scale == 0 && Rest == 2:
+            Ordinal = "hundredth"
+        case Rest == 1:
+            Ordinal = "ten-" + enScales[Scale] + "th"
+        case Rest == 2:
+            Ordinal = "hundred-" + enScales[Scale] + "th"
+        default:
+            Ordinal = enScales[Scale] + "th"
+        }
+        if Numerator != "1" {
+            Ordinal = Ordinal + "s"
+        }
+        return Words + " " + Ordinal, nil
+    }
+}
+
+// ================================================
+//
+// enBelowThousand spells out a number between 1 and 999 in English.
+func enBelowThousand(Number int) string {
+    var Words []string
+    if Number >= 100 {
+        Words = append(Words, enUnits[Number/100], "hundred")
+        Number = Number % 100
+    }
+    switch {
+    case Number >= 20 && Number%10 != 0:
+        Words = append(Words, enTens[Number/10]+"-"+enUnits[Number%10])
+    case Number >= 20:
+        Words = append(Words, enTens[Number/10])
+    case Number > 0:
+        Words = append(Words, enUnits[Number])
+    }
+    return strings.Join(Words, " ")
+}
+
+// ================================================
+//
+// roBelowThousand spells out a number between 1 and 999 in Romanian,
+// agreeing 1, 2 and 12 with the Gender of the counted noun.
+func roBelowThousand(Number int, Gender int) string {
+    var Words []string
+    Unit := func(n int) string {
+        switch {
+        case n == 1 && Gender == roFeminine:
+            return "una"
+        case n == 2 && Gender != roMasculine:
+            return "două"
+        case n == 12 && Gender != roMasculine:
+            return "douăsprezece"
+        }
+        return roUnits[n]
+    }
+    
+    switch Hundreds := Number / 100; {
+    case Hundreds == 1:
+        Words = append(Words, "o sută")
+    case Hundreds == 2:
+        Words = append(Words, "două sute")
+    case Hundreds > 2:
+        Words = append(Words, roUnits[Hundreds]+" sute")
+    }
+    Number = Number % 100
+    switch {
+    case Number >= 20 && Number%10 != 0:
+        Words = append(Words, roTens[Number/10]+" și "+Unit(Number%10))
+    case Number >= 20:
+        Words = append(Words, roTens[Number/10])
+    case Number > 0:
+        Words = append(Words, Unit(Number))
+    }
+    return strings.Join(Words, " ")
+}
+
+// ================================================
+//
+// roCounted joins a Romanian numeral with the counted noun: a count of one uses
+// the article One ("un", "o") and the Singular noun, while counts whose last
+// two digits are 00 or 20 to 99 take the "de" preposition before the Plural noun.
+func roCounted(Digits string, Words string, One string, Singular string, Plural string) string {
+    Digits = strings.TrimLeft(Digits, "0")
+    if Digits == "1" && One != "" {
+        return One + " " + Singular
+    }
+    LastTwo := 0
+    if len(Digits) >= 2 {
+        LastTwo, _ = strconv.Atoi(Digits[len(Digits)-2:])
+    } else {
+        LastTwo, _ = strconv.Atoi(Digits)
+    }
+    if len(Digits) >= 2 && (LastTwo == 0 || LastTwo >= 20) {
+        return Words + " de " + Plural
+    }
+    return Words + " " + Plural
+}
@@ -0,0 +1,266 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "errors"
+)
+
+//
+//	        ODESolvers.go				Ordinary Differential Equation Solvers over Decimal State Vectors
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 ODE Types
+//			01  - ODEFunction			Right hand side f(t, y) of the system dy/dt = f(t, y)
+//			02  - ODEPoint				A point (t, y) of a computed trajectory
+//		02 Fixed Step Solvers
+//			01  - RK4Step				Computes a single classic Runge-Kutta 4 step
+//			02  - RK4				Integrates the system using a fixed number of RK4 steps
+//		03 Adaptive Step Solvers
+//			01  - DormandPrince			Integrates the system using the adaptive Dormand-Prince 5(4) method
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 ODE Types
+//		All the state of the solvers is made of decimals, so that the computed
+//		trajectories are deterministic and platform independent.
+//
+// ================================================
+//
+// # Type 01.01 - ODEFunction
+//
+// ODEFunction is the right hand side of the system dy/dt = f(t, y).
+// It must return a slice having the same length as y.
+type ODEFunction func(t *p.Decimal, y []*p.Decimal) []*p.Decimal
+
+// ================================================
+//
+// # Type 01.02 - ODEPoint
+//
+// ODEPoint is a point of a computed trajectory, the state Y at the time T.
+type ODEPoint struct {
+    T *p.Decimal
+    Y []*p.Decimal
+}
+
+// ================================================================================================
+//
+//	02 Fixed Step Solvers
+//
+// ================================================
+//
+// # Function 02.01 - RK4Step
+//
+// RK4Step computes the state at t+h from the state y at t using
+// the classic 4th order Runge-Kutta method.
+// Every operation is truncated to "DecimalPrecision" decimals.
+func RK4Step(DecimalPrecision uint32, f ODEFunction, t *p.Decimal, y []*p.Decimal, h *p.Decimal) []*p.Decimal {
+    HalfStep := DIV(DecimalPrecision, h, p.NFI(2))
+    SixthStep := DIV(DecimalPrecision, h, p.NFI(6))
+    HalfTime := ADD(DecimalPrecision, t, HalfStep)
+    FullTime := ADD(DecimalPrecision, t, h)
+    
+    K1 := f(t, y)
+    K2 := f(HalfTime, odeAxpy(DecimalPrecision, y, HalfStep, K1))
+    K3 := f(HalfTime, odeAxpy(DecimalPrecision, y, HalfStep, K2))
+    K4 := f(FullTime, odeAxpy(DecimalPrecision, y, h, K3))
+    
+    Result := make([]*p.Decimal, len(y))
+    for i := 0; i < len(y); i++ {
+        Slope := SUM(DecimalPrecision, K1[i], MUL(DecimalPrecision, p.NFI(2), K2[i]), MUL(DecimalPrecision, p.NFI(2), K3[i]), K4[i])
+        Result[i] = ADD(DecimalPrecision, y[i], MUL(DecimalPrecision, SixthStep, Slope))
+    }
+    return Result
+}
+
+// ================================================
+//
+// # Function 02.02 - RK4
+//
+// RK4 integrates the system from t0 to t1 starting from the state y0,
+// using "Steps" equal RK4 steps. The returned trajectory contains
+// the starting point and every computed point, the last one being at t1.
+func RK4(DecimalPrecision uint32, f ODEFunction, t0 *p.Decimal, y0 []*p.Decimal, t1 *p.Decimal, Steps int64) ([]ODEPoint, error) {
+    if Steps <= 0 {
+        return nil, errors.New("RK4: the number of steps must be positive")
+    }
+    if DecimalLessThanOrEqual(t1, t0) == true {
+        return nil, errors.New("RK4: the end time must be greater than the start time")
+    }
+    
+    Step := DIV(DecimalPrecision, SUB(DecimalPrecision, t1, t0), p.NFI(Steps))
+    Trajectory := []ODEPoint{{T: t0, Y: y0}}
+    t, y := t0, y0
+    for i := int64(1); i <= Steps; i++ {
+        y = RK4Step(DecimalPrecision, f, t, y, Step)
+        if i == Steps {
+            //The last point is placed exactly at t1, avoiding the truncation residue of the Step.
+            t = t1
+        } else {
+            t = ADD(DecimalPrecision, t, Step)
+        }
+        Trajectory = append(Trajectory, ODEPoint{T: t, Y: y})
+    }
+    return Trajectory, nil
+}
+
+// ================================================================================================
+//
+//	03 Adaptive Step Solvers
+//
+// ================================================
+//
+// Dormand-Prince 5(4) Butcher tableau, as numerator/denominator pairs.
+var (
+    dpNodes = [7][2]int64{{0, 1}, {1, 5}, {3, 10}, {4, 5}, {8, 9}, {1, 1}, {1, 1}}
+    dpA     = [7][6][2]int64{
+        {},
+        {{1, 5}},
+        {{3, 40}, {9, 40}},
+        {{44, 45}, {-56, 15}, {32, 9}},
+        {{19372, 6561}, {-25360, 2187}, {64448, 6561}, {-212, 729}},
+        {{9017, 3168}, {-355, 33}, {46732, 5247}, {49, 176}, {-5103, 18656}},
+        {{35, 384}, {0, 1}, {500, 1113}, {125, 192}, {-2187, 6784}, {11, 84}},
+    }
+    //Difference between the 5th order and the 4th order weights
+    dpE = [7][2]int64{{71, 57600}, {0, 1}, {-71, 16695}, {71, 1920}, {-17253, 339200}, {22, 525}, {-1, 40}}
+)
+
+// ================================================
+//
+// # Function 03.01 - DormandPrince
+//
+// DormandPrince integrates the system from t0 to t1 starting from the state y0
+// using the adaptive Dormand-Prince 5(4) method, starting with the step h0.
+// A step is accepted when, for every component, the local error estimate is lower than
+// AbsTol + RelTol * max(|y|, |ynew|). The step size is then scaled by
+// 0.9 * ErrorRatio^(-1/5), bounded between 0.2 and 5.
+// An error is returned if MaxSteps attempts are not enough to reach t1.
+func DormandPrince(DecimalPrecision uint32, f ODEFunction, t0 *p.Decimal, y0 []*p.Decimal, t1, h0, AbsTol, RelTol *p.Decimal, MaxSteps int) ([]ODEPoint, error) {
+    if DecimalLessThanOrEqual(t1, t0) == true {
+        return nil, errors.New("DormandPrince: the end time must be greater than the start time")
+    }
+    if DecimalLessThanOrEqual(h0, p.NFI(0)) == true {
+        return nil, errors.New("DormandPrince: the initial step must be positive")
+    }
+    if DecimalLessThan(AbsTol, p.NFI(0)) == true || DecimalLessThan(RelTol, p.NFI(0)) == true ||
+        AbsTol.IsZero() && RelTol.IsZero() {
+        return nil, errors.New("DormandPrince: tolerances must be non negative and not both zero")
+    }
+    
+    //Tableau coefficients computed once at the requested precision
+    Fraction := func(v [2]int64) *p.Decimal {
+        return DIV(DecimalPrecision, p.NFI(v[0]), p.NFI(v[1]))
+    }
+    var (
+        Nodes   [7]*p.Decimal
+        A       [7][6]*p.Decimal
+        E       [7]*p.Decimal
+        Safety  = p.NFS("0.9")
+        MinGrow = p.NFS("0.2")
+        MaxGrow = p.NFI(5)
+        Root    = p.NFS("-0.2")
+    )
+    for i := 0; i < 7; i++ {
+        Nodes[i] = Fraction(dpNodes[i])
+        E[i] = Fraction(dpE[i])
+        for j := 0; j < i; j++ {
+            A[i][j] = Fraction(dpA[i][j])
+        }
+    }
+    
+    Trajectory := []ODEPoint{{T: t0, Y: y0}}
+    t, y, h := t0, y0, h0
+    for Attempt := 0; Attempt < MaxSteps; Attempt++ {
+        Last := false
+        if DecimalGreaterThanOrEqual(ADD(DecimalPrecision, t, h), t1) == true {
+            h = SUB(DecimalPrecision, t1, t)
+            Last = true
+        }
+        
+        //Stages, the 7th one being evaluated at the 5th order solution y1
+        var (
+            K  [7][]*p.Decimal
+            y1 []*p.Decimal
+        )
+        K[0] = f(t, y)
+        for s := 1; s < 7; s++ {
+            Stage := make([]*p.Decimal, len(y))
+            for i := 0; i < len(y); i++ {
+                Slope := p.NFI(0)
+                for j := 0; j < s; j++ {
+                    Slope = ADD(DecimalPrecision, Slope, MUL(DecimalPrecision, A[s][j], K[j][i]))
+                }
+                Stage[i] = ADD(DecimalPrecision, y[i], MUL(DecimalPrecision, h, Slope))
+            }
+            K[s] = f(ADD(DecimalPrecision, t, MUL(DecimalPrecision, Nodes[s], h)), Stage)
+            y1 = Stage
+        }
+        
+        //Error estimate, as the maximum ratio between the local error and the tolerance.
+        //A zero tolerance (AbsTol zero and a zero component) accepts only a zero local error,
+        //a non-zero one making the ratio Unbounded, so that the step is rejected and shrunk.
+        var (
+            ErrorRatio = p.NFI(0)
+            Unbounded  bool
+        )
+        for i := 0; i < len(y); i++ {
+            Estimate := p.NFI(0)
+            for j := 0; j < 7; j++ {
+                Estimate = ADD(DecimalPrecision, Estimate, MUL(DecimalPrecision, E[j], K[j][i]))
+            }
+            Estimate = new(p.Decimal).Abs(MUL(DecimalPrecision, h, Estimate))
+            Scale := MaxDecimal(new(p.Decimal).Abs(y[i]), new(p.Decimal).Abs(y1[i]))
+            Scale = ADD(DecimalPrecision, AbsTol, MUL(DecimalPrecision, RelTol, Scale))
+            if Scale.IsZero() == true {
+                Unbounded = Unbounded || Estimate.IsZero() == false
+                continue
+            }
+            ErrorRatio = MaxDecimal(ErrorRatio, DIV(DecimalPrecision, Estimate, Scale))
+        }
+        
+        //Step size factor
+        Factor := MaxGrow
+        if Unbounded == true {
+            Factor = MinGrow
+        } else if ErrorRatio.IsZero() == false {
+            Factor = MUL(DecimalPrecision, Safety, POWx(DecimalPrecision+1, ErrorRatio, Root))
+            Factor = MinDecimal(MaxGrow, MaxDecimal(MinGrow, Factor))
+        }
+        
+        if Unbounded == false && DecimalLessThanOrEqual(ErrorRatio, p.NFI(1)) == true {
+            if Last == true {
+                t = t1
+            } else {
+                t = ADD(DecimalPrecision, t, h)
+            }
+            y = y1
+            Trajectory = append(Trajectory, ODEPoint{T: t, Y: y})
+            if Last == true {
+                return Trajectory, nil
+            }
+        }
+        h = MUL(DecimalPrecision, h, Factor)
+        if h.IsZero() == true {
+            return Trajectory, errors.New("DormandPrince: step size underflowed the working precision")
+        }
+    }
+    return Trajectory, errors.New("DormandPrince: maximum number of steps reached before the end time")
+}
+
+// ================================================
+//
+// odeAxpy returns y + a * x computed element-wise on decimal state vectors.
+func odeAxpy(DecimalPrecision uint32, y []*p.Decimal, a *p.Decimal, x []*p.Decimal) []*p.Decimal {
+    Result := make([]*p.Decimal, len(y))
+    for i := 0; i < len(y); i++ {
+        Result[i] = ADD(DecimalPrecision, y[i], MUL(DecimalPrecision, a, x[i]))
+    }
+    return Result
+}
@@ -0,0 +1,30 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "testing"
+)
+
+// A component staying zero with AbsTol zero has a zero tolerance scale,
+// which must not turn the error ratio into 0/0.
+func TestDormandPrinceZeroComponentWithoutAbsTol(t *testing.T) {
+    Derivative := func(t *p.Decimal, y []*p.Decimal) []*p.Decimal {
+        return []*p.Decimal{p.NFI(0), y[1]}
+    }
+    Trajectory, err := DormandPrince(30, Derivative, p.NFI(0), []*p.Decimal{p.NFI(0), p.NFI(1)},
+        p.NFI(1), p.NFS("0.1"), p.NFI(0), p.NFS("1E-6"), 1000)
+    if err != nil {
+        t.Fatal(err)
+    }
+    Last := Trajectory[len(Trajectory)-1]
+    if DecimalNotEqual(Last.T, p.NFI(1)) == true {
+        t.Fatalf("trajectory ends at %s, want 1", Last.T.String())
+    }
+    if Last.Y[0].IsZero() == false {
+        t.Errorf("y0 = %s, want 0", Last.Y[0].String())
+    }
+    Error := new(p.Decimal).Abs(SUB(30, Last.Y[1], EXP(30, p.NFI(1))))
+    if DecimalGreaterThan(Error, p.NFS("1E-5")) == true {
+        t.Errorf("y1 = %s, want e within 1E-5", Last.Y[1].String())
+    }
+}
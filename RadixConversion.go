@@ -0,0 +1,175 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "fmt"
+    "strings"
+)
+
+//
+//	        RadixConversion.go			Printing and Parsing Decimals in Base 2 to 36
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Radix Conversion Functions
+//			01  - ToBase				Converts a decimal into a string in the given radix
+//			02  - ParseBase				Parses a string written in the given radix into a decimal
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Radix Conversion Functions
+//		Generalization of the digit splitting done by AttoPlasm2String for base 10.
+//		Digits above 9 are the letters "a" to "z", as used by strconv.
+//
+// ================================================
+//
+// MinRadix and MaxRadix are the supported bases, RadixDigits holds their digits.
+const (
+    MinRadix    = 2
+    MaxRadix    = 36
+    RadixDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+)
+
+// ================================================
+//
+// # Function 01.01 - ToBase
+//
+// ToBase converts the decimal into a string written in base "Radix" (2 to 36).
+// The integer part is converted exactly, by repeated division with the Radix.
+// The fractional part is converted by repeated multiplication with the Radix,
+// and truncated to "FractionalDigits" digits. With zero FractionalDigits only
+// the integer part is printed. Negative numbers are prefixed with "-".
+// For instance 255.5 with one fractional digit gives "ff.8" in base 16
+// and "11111111.1" in base 2.
+func ToBase(Number *p.Decimal, Radix int, FractionalDigits uint32) (string, error) {
+    if Radix < MinRadix || Radix > MaxRadix {
+        return "", fmt.Errorf("ToBase: radix %d is outside the %d to %d interval", Radix, MinRadix, MaxRadix)
+    }
+    
+    var (
+        Builder     strings.Builder
+        IntegerPart []byte
+        R           = p.NFI(int64(Radix))
+        Zero        = p.NFI(0)
+        Absolute    = new(p.Decimal).Abs(Number)
+    )
+    
+    //Positive exponents are brought to zero, so that the digit counting functions work.
+    if Absolute.Exponent > 0 {
+        Absolute = MUL(0, Absolute, p.NFI(1))
+    }
+    Whole := RemoveDecimals(Absolute)
+    Decimals := uint32(0 - Absolute.Exponent)
+    Fraction := SUB(Decimals, Absolute, Whole)
+    
+    //Integer Part, digits are obtained from the least significant one.
+    for DecimalGreaterThan(Whole, Zero) == true {
+        Digit := p.INT64(TruncateCustom(DivMod(Whole, R), 0))
+        IntegerPart = append(IntegerPart, RadixDigits[Digit])
+        Whole = TruncateCustom(DivInt(Whole, R), 0)
+    }
+    if len(IntegerPart) == 0 {
+        IntegerPart = append(IntegerPart, '0')
+    }
+    
+    
+    //Fractional Part, digits are obtained from the most significant one.
+    FractionalPart := make([]byte, 0, FractionalDigits)
+    for i := uint32(0); i < FractionalDigits; i++ {
+        Fraction = MUL(Decimals, Fraction, R)
+        DigitValue := RemoveDecimals(Fraction)
+        FractionalPart = append(FractionalPart, RadixDigits[p.INT64(DigitValue)])
+        Fraction = SUB(Decimals, Fraction, DigitValue)
+    }
+    
+    //The sign is written only if a non-zero digit was emitted, so that -0.5 with no
+    //fractional digits prints as 0 instead of -0.
+    if Number.Negative == true && strings.Trim(string(IntegerPart)+string(FractionalPart), "0") != "" {
+        Builder.WriteString("-")
+    }
+    for i := len(IntegerPart) - 1; i >= 0; i-- {
+        Builder.WriteByte(IntegerPart[i])
+    }
+    if FractionalDigits > 0 {
+        Builder.WriteString(".")
+        Builder.Write(FractionalPart)
+    }
+    return Builder.String(), nil
+}
+
+// ================================================
+//
+// # Function 01.02 - ParseBase
+//
+// ParseBase parses a string written in base "Radix" (2 to 36) into a decimal.
+// An optional "+" or "-" sign and a single "." fractional separator are accepted,
+// digits are case-insensitive. Fractional digits are converted exactly when
+// the Radix has only 2 and 5 as prime factors (like 2, 8, 16 or 32) and the
+// value fits in MaxMathPrecision decimals; otherwise the fractional part is
+// truncated to MaxMathPrecision decimals.
+func ParseBase(Text string, Radix int) (*p.Decimal, error) {
+    if Radix < MinRadix || Radix > MaxRadix {
+        return nil, fmt.Errorf("ParseBase: radix %d is outside the %d to %d interval", Radix, MinRadix, MaxRadix)
+    }
+    
+    var (
+        Negative bool
+        R        = p.NFI(int64(Radix))
+    )
+    Body := Text
+    if strings.HasPrefix(Body, "-") || strings.HasPrefix(Body, "+") {
+        Negative = Body[0] == '-'
+        Body = Body[1:]
+    }
+    IntegerText, FractionText, HasPoint := strings.Cut(Body, ".")
+    if IntegerText == "" && FractionText == "" {
+        return nil, fmt.Errorf("ParseBase: %q contains no digits", Text)
+    }
+    if HasPoint == true && FractionText == "" {
+        return nil, fmt.Errorf("ParseBase: %q has no digits after the fractional separator", Text)
+    }
+    
+    //DigitsValue converts a string of digits into the integer they represent.
+    DigitsValue := func(Digits string) (*p.Decimal, error) {
+        Value := p.NFI(0)
+        for _, Character := range strings.ToLower(Digits) {
+            Digit := strings.IndexRune(RadixDigits, Character)
+            if Digit < 0 || Digit >= Radix {
+                return nil, fmt.Errorf("ParseBase: invalid digit %q for radix %d in %q", Character, Radix, Text)
+            }
+            Value = ADD(0, MUL(0, Value, R), p.NFI(int64(Digit)))
+        }
+        return Value, nil
+    }
+    
+    Result, err := DigitsValue(IntegerText)
+    if err != nil {
+        return nil, err
+    }
+    if FractionText != "" {
+        Numerator, err := DigitsValue(FractionText)
+        if err != nil {
+            return nil, err
+        }
+        Denominator := POWx(uint32(len(FractionText))*2+1, R, p.NFI(int64(len(FractionText))))
+        Fraction := DIV(MaxMathPrecision, Numerator, Denominator)
+        //Trailing zeros of the division are removed, the Fraction being lower than 1
+        //its Exponent remains negative.
+        if Fraction.IsZero() == true {
+            Fraction = p.NFI(0)
+        } else {
+            Fraction.Reduce(Fraction)
+        }
+        Result = ADD(MaxMathPrecision, Result, Fraction)
+    }
+    if Negative == true && Result.IsZero() == false {
+        Result.Neg(Result)
+    }
+    return Result, nil
+}
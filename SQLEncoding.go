@@ -0,0 +1,175 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "database/sql/driver"
+    "fmt"
+)
+
+//
+//	        SQLEncoding.go				database/sql Scanner and Valuer for Decimals and Amounts
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Decimal SQL Functions
+//			01  - Decimal.Value			Writes the decimal to a NUMERIC or TEXT column
+//			02  - Decimal.Scan			Reads the decimal from a NUMERIC or TEXT column
+//		02 Amount SQL Functions
+//			01  - Amount.Value			Writes the Amount as a Koson decimal
+//			02  - Amount.Scan			Reads the Amount from a Koson decimal
+//			03  - AttoPlasmAmount.Value		Writes the Amount as an integer number of AttoPlasms
+//			04  - AttoPlasmAmount.Scan		Reads the Amount from an integer number of AttoPlasms
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Decimal SQL Functions
+//		Values are written as text (DTS), which drivers map to NUMERIC and TEXT columns alike.
+//		Writes having more than CurrencyPrecision decimals are rejected instead of being truncated.
+//		Reads accept text, bytes and int64 values; float64 values, exponents, NaN and
+//		values needing truncation to CurrencyPrecision are rejected as lossy.
+//		Trailing zeros beyond CurrencyPrecision (a NUMERIC(40,20) column) are accepted.
+//
+// ================================================
+//
+// # Function 01.01 - Decimal.Value
+//
+// Value implements driver.Valuer. A nil Number is written as NULL.
+func (d Decimal) Value() (driver.Value, error) {
+    if d.Number == nil {
+        return nil, nil
+    }
+    if err := checkSQLScale(d.Number); err != nil {
+        return nil, fmt.Errorf("Decimal.Value: %w", err)
+    }
+    Text, err := d.MarshalText()
+    if err != nil {
+        return nil, err
+    }
+    return string(Text), nil
+}
+
+// ================================================
+//
+// # Function 01.02 - Decimal.Scan
+//
+// Scan implements sql.Scanner. A NULL sets Number to nil.
+func (d *Decimal) Scan(Source interface{}) error {
+    if Source == nil {
+        d.Number = nil
+        return nil
+    }
+    Number, err := scanSQLDecimal(Source, MaxJSONDecimals)
+    if err != nil {
+        return fmt.Errorf("Decimal.Scan: %w", err)
+    }
+    d.Number = Number
+    return nil
+}
+
+// ================================================================================================
+//
+//	02 Amount SQL Functions
+//
+// ================================================
+//
+// # Function 02.01 - Amount.Value
+//
+// Value implements driver.Valuer, writing the Koson value with CurrencyPrecision decimals.
+func (a Amount) Value() (driver.Value, error) {
+    return DTS(a.Koson()), nil
+}
+
+// ================================================
+//
+// # Function 02.02 - Amount.Scan
+//
+// Scan implements sql.Scanner, reading a Koson value. NULL, negative values
+// and fractions smaller than one AttoPlasm are rejected.
+func (a *Amount) Scan(Source interface{}) error {
+    if Source == nil {
+        return fmt.Errorf("Amount.Scan: NULL is not an amount")
+    }
+    Number, err := scanSQLDecimal(Source, MaxJSONDecimals)
+    if err != nil {
+        return fmt.Errorf("Amount.Scan: %w", err)
+    }
+    Value, err := AmountFromKoson(Number)
+    if err != nil {
+        return fmt.Errorf("Amount.Scan: %w", err)
+    }
+    *a = Value
+    return nil
+}
+
+// ================================================
+//
+// # Function 02.03 - AttoPlasmAmount.Value
+//
+// Value implements driver.Valuer, writing the integer number of AttoPlasms.
+func (a AttoPlasmAmount) Value() (driver.Value, error) {
+    return DTS(a.AttoPlasms()), nil
+}
+
+// ================================================
+//
+// # Function 02.04 - AttoPlasmAmount.Scan
+//
+// Scan implements sql.Scanner, reading an integer number of AttoPlasms.
+func (a *AttoPlasmAmount) Scan(Source interface{}) error {
+    if Source == nil {
+        return fmt.Errorf("AttoPlasmAmount.Scan: NULL is not an amount")
+    }
+    Number, err := scanSQLDecimal(Source, MaxJSONDecimals)
+    if err != nil {
+        return fmt.Errorf("AttoPlasmAmount.Scan: %w", err)
+    }
+    Value, err := AmountFromAttoPlasms(Number)
+    if err != nil {
+        return fmt.Errorf("AttoPlasmAmount.Scan: %w", err)
+    }
+    a.Amount = Value
+    return nil
+}
+
+// ================================================
+//
+// scanSQLDecimal converts a driver value into a decimal, rejecting lossy values.
+func scanSQLDecimal(Source interface{}, MaxDecimals uint32) (*p.Decimal, error) {
+    var Text string
+    switch Value := Source.(type) {
+    case string:
+        Text = Value
+    case []byte:
+        Text = string(Value)
+    case int64:
+        return p.NFI(Value), nil
+    case float64:
+        return nil, fmt.Errorf("float64 value %v is lossy, use a NUMERIC or TEXT column", Value)
+    default:
+        return nil, fmt.Errorf("unsupported type %T", Source)
+    }
+    Number, err := ParsePlainDecimal(Text, MaxDecimals)
+    if err != nil {
+        return nil, err
+    }
+    if err := checkSQLScale(Number); err != nil {
+        return nil, err
+    }
+    return Number, nil
+}
+
+// ================================================
+//
+// checkSQLScale returns an error if the decimal has non-zero digits beyond CurrencyPrecision.
+func checkSQLScale(Number *p.Decimal) error {
+    if DecimalNotEqual(MUL(CurrencyPrecision, Number, p.NFI(1)), Number) == true {
+        return fmt.Errorf("%s has more than %d decimals", DTS(Number), CurrencyPrecision)
+    }
+    return nil
+}
@@ -0,0 +1,134 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "context"
+    "database/sql"
+    "database/sql/driver"
+    "errors"
+    "io"
+    "sync"
+    "testing"
+)
+
+// ================================================
+//
+// stubConnector is an in-memory database/sql driver holding a single stored value:
+// every statement with arguments stores its first argument, every statement without arguments reads it.
+type stubConnector struct {
+    mu     sync.Mutex
+    stored driver.Value
+}
+
+type stubConn struct{ connector *stubConnector }
+type stubStmt struct{ connector *stubConnector }
+type stubRows struct {
+    value driver.Value
+    done  bool
+}
+
+func (c *stubConnector) Connect(context.Context) (driver.Conn, error) { return stubConn{c}, nil }
+func (c *stubConnector) Driver() driver.Driver                        { return nil }
+func (c stubConn) Prepare(string) (driver.Stmt, error)                { return stubStmt(c), nil }
+func (c stubConn) Close() error                                       { return nil }
+func (c stubConn) Begin() (driver.Tx, error)                          { return nil, errors.New("no transactions") }
+func (s stubStmt) Close() error                                       { return nil }
+func (s stubStmt) NumInput() int                                      { return -1 }
+func (r *stubRows) Columns() []string                                 { return []string{"value"} }
+func (r *stubRows) Close() error                                      { return nil }
+
+func (s stubStmt) Exec(Arguments []driver.Value) (driver.Result, error) {
+    s.connector.mu.Lock()
+    defer s.connector.mu.Unlock()
+    s.connector.stored = Arguments[0]
+    return driver.RowsAffected(1), nil
+}
+
+func (s stubStmt) Query([]driver.Value) (driver.Rows, error) {
+    s.connector.mu.Lock()
+    defer s.connector.mu.Unlock()
+    return &stubRows{value: s.connector.stored}, nil
+}
+
+func (r *stubRows) Next(Destination []driver.Value) error {
+    if r.done == true {
+        return io.EOF
+    }
+    r.done = true
+    Destination[0] = r.value
+    return nil
+}
+
+// ================================================
+//
+// sqlRoundTrip stores Value in a fresh stub database and scans it back into Target.
+func sqlRoundTrip(t *testing.T, Value interface{}, Target interface{}) error {
+    t.Helper()
+    DB := sql.OpenDB(&stubConnector{})
+    defer DB.Close()
+    if _, err := DB.Exec("INSERT", Value); err != nil {
+        return err
+    }
+    return DB.QueryRow("SELECT").Scan(Target)
+}
+
+func TestSQLDecimalRoundTrip(t *testing.T) {
+    for _, Text := range []string{"0", "-12.345", "1000", "123456789.123456789012345678"} {
+        var Scanned Decimal
+        if err := sqlRoundTrip(t, Decimal{p.NFS(Text)}, &Scanned); err != nil {
+            t.Fatalf("%s: %v", Text, err)
+        }
+        if DecimalNotEqual(Scanned.Number, p.NFS(Text)) == true {
+            t.Errorf("%s: scanned %s", Text, Scanned.Number.String())
+        }
+    }
+    Scanned := Decimal{p.NFI(1)}
+    if err := sqlRoundTrip(t, Decimal{}, &Scanned); err != nil || Scanned.Number != nil {
+        t.Errorf("NULL: scanned %v, %v", Scanned.Number, err)
+    }
+}
+
+func TestSQLAmountRoundTrip(t *testing.T) {
+    Original, err := AmountFromKoson(p.NFS("1234.000000000000000001"))
+    if err != nil {
+        t.Fatal(err)
+    }
+    var Scanned Amount
+    if err := sqlRoundTrip(t, Original, &Scanned); err != nil {
+        t.Fatal(err)
+    }
+    if Scanned.Cmp(Original) != 0 {
+        t.Errorf("Amount: scanned %s, want %s", Scanned.String(), Original.String())
+    }
+    var ScannedAtto AttoPlasmAmount
+    if err := sqlRoundTrip(t, AttoPlasmAmount{Original}, &ScannedAtto); err != nil {
+        t.Fatal(err)
+    }
+    if ScannedAtto.Cmp(Original) != 0 {
+        t.Errorf("AttoPlasmAmount: scanned %s, want %s", ScannedAtto.String(), Original.String())
+    }
+}
+
+func TestSQLRejectsFloat64(t *testing.T) {
+    for _, Target := range []interface{}{&Decimal{}, &Amount{}, &AttoPlasmAmount{}} {
+        if err := sqlRoundTrip(t, 1.5, Target); err == nil {
+            t.Errorf("%T: float64 was accepted", Target)
+        }
+    }
+}
+
+func TestSQLRejectsOverScale(t *testing.T) {
+    OverScale := "1.0000000000000000001"
+    if err := sqlRoundTrip(t, Decimal{p.NFS(OverScale)}, &Decimal{}); err == nil {
+        t.Errorf("Decimal.Value accepted %s", OverScale)
+    }
+    for _, Target := range []interface{}{&Decimal{}, &Amount{}, &AttoPlasmAmount{}} {
+        if err := sqlRoundTrip(t, OverScale, Target); err == nil {
+            t.Errorf("%T: %s was accepted", Target, OverScale)
+        }
+    }
+    var Scanned Decimal
+    if err := sqlRoundTrip(t, "2.50000000000000000000", &Scanned); err != nil || DecimalNotEqual(Scanned.Number, p.NFS("2.5")) == true {
+        t.Errorf("trailing zeros: scanned %v, %v", Scanned.Number, err)
+    }
+}
@@ -0,0 +1,146 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "fmt"
+    "strings"
+)
+
+//
+//	        ScientificNotation.go			Scientific, Engineering and SI Prefix Formatting
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Notation Functions
+//			01  - FormatScientific			Formats a decimal as d.ddd E±n with a number of significant figures
+//			02  - FormatEngineering			Formats a decimal as ddd.d E±n, n being a multiple of 3
+//			03  - FormatSI				Formats a decimal using SI prefixes (k, M, G, m, µ, n...)
+//			04  - SignificantDigits			Returns the significant digits and the exponent of a decimal
+//			05  - EngineeringParts			Returns the engineering mantissa and its exponent
+//			06  - NotationMantissa			Places the point and the sign into a mantissa
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Notation Functions
+//		Significant figures are truncated, never rounded up, as the rest of the library does,
+//		so a displayed amount is never greater than the real one.
+//		The conversions are done on the decimal digits, without going through float64.
+//
+// ================================================
+//
+// SIPrefixes maps the exponents multiple of 3 to their SI prefix.
+var SIPrefixes = map[int64]string{
+    -30: "q", -27: "r", -24: "y", -21: "z", -18: "a", -15: "f", -12: "p", -9: "n", -6: "µ", -3: "m",
+    0: "", 3: "k", 6: "M", 9: "G", 12: "T", 15: "P", 18: "E", 21: "Z", 24: "Y", 27: "R", 30: "Q",
+}
+
+// ================================================
+//
+// # Function 01.01 - FormatScientific
+//
+// FormatScientific converts the decimal into scientific notation having
+// "SigFigs" significant figures (at least 1), for instance
+// 123456.789 with 4 significant figures becomes 1.234E+5
+// and 0.000012345 with 3 significant figures becomes 1.23E-5
+func FormatScientific(Number *p.Decimal, SigFigs int) string {
+    Digits, Exponent := SignificantDigits(Number, SigFigs)
+    return NotationMantissa(Number, Digits, 1) + fmt.Sprintf("E%+d", Exponent)
+}
+
+// ================================================
+//
+// # Function 01.02 - FormatEngineering
+//
+// FormatEngineering converts the decimal into engineering notation having
+// "SigFigs" significant figures (at least 1), the exponent being a multiple of 3.
+// 123456.789 with 4 significant figures becomes 123.4E+3.
+// When SigFigs are fewer than the integer digits of the mantissa, these are padded with zeros:
+// 123456.789 with 1 significant figure becomes 100E+3
+func FormatEngineering(Number *p.Decimal, SigFigs int) string {
+    Mantissa, Exponent := EngineeringParts(Number, SigFigs)
+    return Mantissa + fmt.Sprintf("E%+d", Exponent)
+}
+
+// ================================================
+//
+// # Function 01.03 - FormatSI
+//
+// FormatSI converts the decimal into engineering notation, replacing the
+// exponent with its SI prefix, followed by the Unit:
+// 1234567 with 3 significant figures and "CP" as Unit becomes "1.23 MCP",
+// 0.000000123 with 3 significant figures and "CP" as Unit becomes "123 nCP".
+// Numbers outside the SI prefix range (1E-30 to 1E+33) use the engineering E notation.
+func FormatSI(Number *p.Decimal, SigFigs int, Unit string) string {
+    Mantissa, Exponent := EngineeringParts(Number, SigFigs)
+    Prefix, Exists := SIPrefixes[Exponent]
+    if Exists == false {
+        Mantissa = Mantissa + fmt.Sprintf("E%+d", Exponent)
+    }
+    if Prefix+Unit == "" {
+        return Mantissa
+    }
+    return Mantissa + " " + Prefix + Unit
+}
+
+// ================================================
+//
+// # Function 01.04 - SignificantDigits
+//
+// SignificantDigits returns the first "SigFigs" significant digits of the decimal
+// (padded with zeros if the number has fewer) and the power of ten of the first one.
+// 123456.789 with 4 SigFigs returns "1234" and 5. Zero returns zeros and 0.
+func SignificantDigits(Number *p.Decimal, SigFigs int) (string, int64) {
+    if SigFigs < 1 {
+        SigFigs = 1
+    }
+    if Number.IsZero() == true {
+        return strings.Repeat("0", SigFigs), 0
+    }
+    
+    Coefficient := strings.TrimLeft(Number.Coeff.Text(10), "0")
+    Exponent := int64(len(Coefficient)) - 1 + int64(Number.Exponent)
+    if len(Coefficient) >= SigFigs {
+        return Coefficient[:SigFigs], Exponent
+    }
+    return Coefficient + strings.Repeat("0", SigFigs-len(Coefficient)), Exponent
+}
+
+// ================================================
+//
+// # Function 01.05 - EngineeringParts
+//
+// EngineeringParts returns the engineering mantissa string and its exponent, a multiple of 3.
+func EngineeringParts(Number *p.Decimal, SigFigs int) (string, int64) {
+    Digits, Exponent := SignificantDigits(Number, SigFigs)
+    Shift := Exponent % 3
+    if Shift < 0 {
+        Shift = Shift + 3
+    }
+    IntegerDigits := int(Shift) + 1
+    if len(Digits) < IntegerDigits {
+        Digits = Digits + strings.Repeat("0", IntegerDigits-len(Digits))
+    }
+    return NotationMantissa(Number, Digits, IntegerDigits), Exponent - Shift
+}
+
+// ================================================
+//
+// # Function 01.06 - NotationMantissa
+//
+// NotationMantissa places the point after "IntegerDigits" digits and adds the sign of the Number.
+func NotationMantissa(Number *p.Decimal, Digits string, IntegerDigits int) string {
+    Mantissa := Digits[:IntegerDigits]
+    if len(Digits) > IntegerDigits {
+        Mantissa = Mantissa + "." + Digits[IntegerDigits:]
+    }
+    if Number.Negative == true && Number.IsZero() == false {
+        Mantissa = "-" + Mantissa
+    }
+    return Mantissa
+}
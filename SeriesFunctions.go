@@ -0,0 +1,273 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "errors"
+)
+
+//
+//	        SeriesFunctions.go			Infinite Series Summation and Convergence Acceleration
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Series Types
+//			01  - SeriesTerm			Generator returning the n-th term of a series
+//		02 Summation Functions
+//			01  - SeriesTerms			Returns the first N terms of a series
+//			02  - PartialSums			Returns the first N partial sums of a series
+//			03  - SumSeries				Sums a series until its terms drop below the working precision
+//		03 Convergence Accelerators
+//			01  - AitkenDelta2			Aitken's delta-squared transform of a sequence of partial sums
+//			02  - RichardsonExtrapolation		Richardson extrapolation of a sequence of partial sums
+//			03  - EulerTransform			Euler transform of an alternating series
+//			04  - LevinU				Levin u-transform of a series
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Series Types
+//
+// ================================================
+//
+// # Type 01.01 - SeriesTerm
+//
+// SeriesTerm returns the n-th term of a series, n starting from 0.
+type SeriesTerm func(n int64) *p.Decimal
+
+// SeriesGuardDigits are the extra decimals used internally by the accelerators,
+// since their weights cancel each other out. Results are truncated back
+// to the requested decimal precision.
+const SeriesGuardDigits = uint32(10)
+
+// ================================================================================================
+//
+//	02 Summation Functions
+//
+// ================================================
+//
+// # Function 02.01 - SeriesTerms
+//
+// SeriesTerms returns the first N terms of the series, as a slice of decimals.
+func SeriesTerms(Term SeriesTerm, N int64) []*p.Decimal {
+    var Terms = make([]*p.Decimal, 0)
+    for n := int64(0); n < N; n++ {
+        Terms = append(Terms, Term(n))
+    }
+    return Terms
+}
+
+// ================================================
+//
+// # Function 02.02 - PartialSums
+//
+// PartialSums returns the first N partial sums S(0) ... S(N-1) of the series,
+// S(n) being the sum of the terms 0 to n.
+// Additions are done with "DecimalPrecision" decimal precision.
+func PartialSums(DecimalPrecision uint32, Term SeriesTerm, N int64) []*p.Decimal {
+    var (
+        Sums = make([]*p.Decimal, 0)
+        Sum  = p.NFI(0)
+    )
+    for n := int64(0); n < N; n++ {
+        Sum = ADD(DecimalPrecision, Sum, Term(n))
+        Sums = append(Sums, Sum)
+    }
+    return Sums
+}
+
+// ================================================
+//
+// # Function 02.03 - SumSeries
+//
+// SumSeries adds the terms of the series until two consecutive terms are, in absolute value,
+// lower than 10^(-DecimalPrecision), that is until they can no longer change the sum
+// at the working precision. Two terms are required so that series having
+// zero terms at some positions (like odd or even only series) are not cut short.
+// It returns the sum and the number of terms used. An error is returned
+// if the series has not converged after MaxTerms terms.
+func SumSeries(DecimalPrecision uint32, Term SeriesTerm, MaxTerms int64) (*p.Decimal, int64, error) {
+    var (
+        Sum        = p.NFI(0)
+        SmallTerms = 0
+        Epsilon    = p.New(1, 0-int32(DecimalPrecision))
+    )
+    for n := int64(0); n < MaxTerms; n++ {
+        Value := Term(n)
+        Sum = ADD(DecimalPrecision, Sum, Value)
+        if DecimalLessThan(new(p.Decimal).Abs(Value), Epsilon) == true {
+            SmallTerms++
+            if SmallTerms == 2 {
+                return Sum, n + 1, nil
+            }
+        } else {
+            SmallTerms = 0
+        }
+    }
+    return Sum, MaxTerms, errors.New("SumSeries: series did not converge within the maximum number of terms")
+}
+
+// ================================================================================================
+//
+//	03 Convergence Accelerators
+//		Functions that estimate the limit of slowly converging series
+//		from a limited number of terms or partial sums.
+//
+// ================================================
+//
+// # Function 03.01 - AitkenDelta2
+//
+// AitkenDelta2 applies Aitken's delta-squared process to a sequence of partial sums:
+// A(n) = S(n) - (S(n+1) - S(n))^2 / (S(n+2) - 2*S(n+1) + S(n))
+// The resulted sequence is 2 elements shorter than the input.
+// Where the second difference vanishes, S(n+2) is used as the accelerated value.
+// The process can be repeated on its own output.
+func AitkenDelta2(DecimalPrecision uint32, Sums []*p.Decimal) []*p.Decimal {
+    var (
+        Accelerated = make([]*p.Decimal, 0)
+        WP          = DecimalPrecision + SeriesGuardDigits
+    )
+    for n := 0; n+2 < len(Sums); n++ {
+        Delta := SUB(WP, Sums[n+1], Sums[n])
+        Delta2 := SUB(WP, SUB(WP, Sums[n+2], Sums[n+1]), Delta)
+        if Delta2.IsZero() == true {
+            Accelerated = append(Accelerated, TruncateCustom(new(p.Decimal).Set(Sums[n+2]), DecimalPrecision))
+            continue
+        }
+        Correction := DIV(WP, MUL(WP, Delta, Delta), Delta2)
+        Accelerated = append(Accelerated, TruncateCustom(SUB(WP, Sums[n], Correction), DecimalPrecision))
+    }
+    return Accelerated
+}
+
+// ================================================
+//
+// # Function 03.02 - RichardsonExtrapolation
+//
+// RichardsonExtrapolation estimates the limit of a sequence of partial sums
+// whose error behaves like a power series in 1/n, as is the case for many
+// slowly converging series (for instance the sum of 1/n^2).
+// The last Order+1 partial sums are used, Sums[i] being the partial sum of i+1 terms:
+// R = sum over k=0..Order of S(n+k) * (n+k)^Order * (-1)^(k+Order) / (k! * (Order-k)!)
+func RichardsonExtrapolation(DecimalPrecision uint32, Sums []*p.Decimal, Order int) (*p.Decimal, error) {
+    if Order < 1 {
+        return nil, errors.New("RichardsonExtrapolation: the order must be at least 1")
+    }
+    if len(Sums) < Order+1 {
+        return nil, errors.New("RichardsonExtrapolation: not enough partial sums for the requested order")
+    }
+    
+    var (
+        WP         = DecimalPrecision + SeriesGuardDigits
+        Result     = p.NFI(0)
+        Factorials = []*p.Decimal{p.NFI(1)}
+        First      = len(Sums) - Order - 1
+    )
+    for k := 1; k <= Order; k++ {
+        Factorials = append(Factorials, MUL(WP, Factorials[k-1], p.NFI(int64(k))))
+    }
+    
+    for k := 0; k <= Order; k++ {
+        //(n+k)^Order is an integer, computed exactly since the weights cancel each other out.
+        N, Weight := p.NFI(int64(First+k+1)), p.NFI(1)
+        for j := 0; j < Order; j++ {
+            Weight = MUL(0, Weight, N)
+        }
+        Weight = DIV(WP, Weight, MUL(WP, Factorials[k], Factorials[Order-k]))
+        if (k+Order)%2 == 1 {
+            Weight.Neg(Weight)
+        }
+        Result = ADD(WP, Result, MUL(WP, Weight, Sums[First+k]))
+    }
+    return TruncateCustom(Result, DecimalPrecision), nil
+}
+
+// ================================================
+//
+// # Function 03.03 - EulerTransform
+//
+// EulerTransform computes the sum of the alternating series
+// a(0) - a(1) + a(2) - a(3) + ... using the Euler transform:
+// sum over k of (-1)^k * Delta^k a(0) / 2^(k+1)
+// where Delta is the forward difference operator.
+// Terms holds the magnitudes a(n), without the alternating sign.
+func EulerTransform(DecimalPrecision uint32, Terms []*p.Decimal) *p.Decimal {
+    var (
+        WP          = DecimalPrecision + SeriesGuardDigits
+        Result      = p.NFI(0)
+        Differences = make([]*p.Decimal, len(Terms))
+        PowerOfTwo  = p.NFI(2)
+    )
+    copy(Differences, Terms)
+    
+    for k := 0; k < len(Terms); k++ {
+        Value := DIV(WP, Differences[0], PowerOfTwo)
+        if k%2 == 1 {
+            Value.Neg(Value)
+        }
+        Result = ADD(WP, Result, Value)
+        
+        //Next order of forward differences
+        for i := 0; i+1 < len(Differences); i++ {
+            Differences[i] = SUB(WP, Differences[i+1], Differences[i])
+        }
+        Differences = Differences[:len(Differences)-1]
+        PowerOfTwo = MUL(WP, PowerOfTwo, p.NFI(2))
+    }
+    return TruncateCustom(Result, DecimalPrecision)
+}
+
+// ================================================
+//
+// # Function 03.04 - LevinU
+//
+// LevinU estimates the sum of the series having the given Terms
+// using the Levin u-transform, with the remainder estimates w(j) = (j+1) * a(j):
+// L = sum(C(k,j) * (-1)^j * ((j+1)/(k+1))^(k-1) * S(j)/w(j)) / sum(C(k,j) * (-1)^j * ((j+1)/(k+1))^(k-1) / w(j))
+// where k+1 is the number of terms. It works for both alternating
+// and monotone logarithmically converging series. Terms must not be zero.
+func LevinU(DecimalPrecision uint32, Terms []*p.Decimal) (*p.Decimal, error) {
+    if len(Terms) < 2 {
+        return nil, errors.New("LevinU: at least 2 terms are required")
+    }
+    
+    var (
+        WP          = DecimalPrecision + SeriesGuardDigits
+        K           = int64(len(Terms) - 1)
+        Numerator   = p.NFI(0)
+        Denominator = p.NFI(0)
+        Sum         = p.NFI(0)
+        Binomial    = p.NFI(1)
+    )
+    for j := int64(0); j <= K; j++ {
+        if Terms[j].IsZero() == true {
+            return nil, errors.New("LevinU: terms of the series must not be zero")
+        }
+        Sum = ADD(WP, Sum, Terms[j])
+        
+        //The common (k+1)^(k-1) factor cancels out between Numerator and Denominator,
+        //so only the integer (j+1)^(k-1) is computed.
+        Power := p.NFI(1)
+        for e := int64(0); e < K-1; e++ {
+            Power = MUL(WP, Power, p.NFI(j+1))
+        }
+        Remainder := MUL(WP, p.NFI(j+1), Terms[j])
+        Weight := DIV(WP, MUL(WP, Binomial, Power), Remainder)
+        if j%2 == 1 {
+            Weight.Neg(Weight)
+        }
+        Numerator = ADD(WP, Numerator, MUL(WP, Weight, Sum))
+        Denominator = ADD(WP, Denominator, Weight)
+        
+        //Next Binomial Coefficient C(K, j+1) = C(K, j) * (K-j) / (j+1)
+        Binomial = DIV(WP, MUL(WP, Binomial, p.NFI(K-j)), p.NFI(j+1))
+    }
+    if Denominator.IsZero() == true {
+        return nil, errors.New("LevinU: the transform is undefined for these terms")
+    }
+    return TruncateCustom(DIV(WP, Numerator, Denominator), DecimalPrecision), nil
+}
@@ -0,0 +1,458 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "fmt"
+    "time"
+)
+
+//
+//	        TimeValue.go				Time Value of Money Functions
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Time Value Types
+//			01  - PaymentTiming			Whether payments are made at the end or beginning of periods
+//			02  - RateFunction			Function whose root is a rate
+//		02 Annuity Functions
+//			01  - PV				Present value of a series of payments
+//			02  - FV				Future value of a series of payments
+//			03  - PMT				Periodic payment of a loan or investment
+//			04  - NPER				Number of periods of a loan or investment
+//			05  - RATE				Periodic rate of a loan or investment
+//		03 Cashflow Functions
+//			01  - NPV				Net present value of periodic cashflows
+//			02  - IRR				Internal rate of return of periodic cashflows
+//			03  - XNPV				Net present value of dated cashflows
+//			04  - XIRR				Internal rate of return of dated cashflows
+//		04 Rate Solver
+//			01  - SolveRate				Finds a rate root by bisection within a bracket
+//			02  - BracketRate			Finds a bracket containing a rate root
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Time Value Types
+//		The functions follow the spreadsheet conventions: Rate is the rate per period (0.05 for 5%),
+//		money paid out is negative and money received is positive, so PV(0.05, 10, -100, 0)
+//		is positive. Amounts are truncated to CurrencyPrecision, the computations being done with
+//		InterestGuardDigits more decimals. Rates are solved iteratively to a requested number of decimals.
+//
+// ================================================
+//
+// # Type 01.01 - PaymentTiming
+//
+// PaymentTiming sets when the payments are made within each period.
+type PaymentTiming int
+
+const (
+    PayAtEnd       PaymentTiming = iota // Ordinary annuity, spreadsheet type 0
+    PayAtBeginning                      // Annuity due, spreadsheet type 1
+)
+
+// ================================================
+//
+// # Type 01.02 - RateFunction
+//
+// RateFunction computes, for a Rate, a value that is zero at the searched rate.
+type RateFunction func(Rate *p.Decimal) (*p.Decimal, error)
+
+// RateSearchGrid holds the rates tried by BracketRate, in ascending order.
+var RateSearchGrid = []string{"-0.99", "-0.9", "-0.5", "-0.1", "0", "0.1", "0.5", "1", "2", "5", "10", "100", "1000"}
+
+// ================================================================================================
+//
+//	02 Annuity Functions
+//
+// ================================================
+//
+// # Function 02.01 - PV
+//
+// PV returns the present value of Periods payments of Payment at the periodic Rate,
+// followed by the FutureValue: -(FutureValue + Payment * Annuity) / (1 + Rate) ** Periods.
+func PV(Rate, Periods, Payment, FutureValue *p.Decimal, Timing PaymentTiming) (*p.Decimal, error) {
+    Decimals := CurrencyPrecision + InterestGuardDigits
+    Growth, Annuity, err := tvmFactors(Decimals, Rate, Periods, Timing)
+    if err != nil {
+        return nil, fmt.Errorf("PV: %w", err)
+    }
+    Value := DIV(Decimals, ADD(Decimals, FutureValue, MUL(Decimals, Payment, Annuity)), Growth)
+    return TruncToCurrency(Value.Neg(Value)), nil
+}
+
+// ================================================
+//
+// # Function 02.02 - FV
+//
+// FV returns the future value of the PresentValue and of Periods payments of Payment
+// at the periodic Rate: -(PresentValue * (1 + Rate) ** Periods + Payment * Annuity).
+func FV(Rate, Periods, Payment, PresentValue *p.Decimal, Timing PaymentTiming) (*p.Decimal, error) {
+    Decimals := CurrencyPrecision + InterestGuardDigits
+    Growth, Annuity, err := tvmFactors(Decimals, Rate, Periods, Timing)
+    if err != nil {
+        return nil, fmt.Errorf("FV: %w", err)
+    }
+    Value := ADD(Decimals, MUL(Decimals, PresentValue, Growth), MUL(Decimals, Payment, Annuity))
+    return TruncToCurrency(Value.Neg(Value)), nil
+}
+
+// ================================================
+//
+// # Function 02.03 - PMT
+//
+// PMT returns the periodic payment which, over Periods periods at the periodic Rate,
+// turns the PresentValue into the FutureValue: -(FutureValue + PresentValue * (1 + Rate) ** Periods) / Annuity.
+// A loan of 10000 at 0.5% per month for 12 months has a payment of -860.66...
+func PMT(Rate, Periods, PresentValue, FutureValue *p.Decimal, Timing PaymentTiming) (*p.Decimal, error) {
+    Decimals := CurrencyPrecision + InterestGuardDigits
+    Growth, Annuity, err := tvmFactors(Decimals, Rate, Periods, Timing)
+    if err != nil {
+        return nil, fmt.Errorf("PMT: %w", err)
+    }
+    if Annuity.IsZero() == true {
+        return nil, fmt.Errorf("PMT: the number of periods must not be zero")
+    }
+    Value := DIV(Decimals, ADD(Decimals, FutureValue, MUL(Decimals, PresentValue, Growth)), Annuity)
+    return TruncToCurrency(Value.Neg(Value)), nil
+}
+
+// ================================================
+//
+// # Function 02.04 - NPER
+//
+// NPER returns the number of periods needed for the Payment at the periodic Rate to turn
+// the PresentValue into the FutureValue. The result is truncated to CurrencyPrecision.
+func NPER(Rate, Payment, PresentValue, FutureValue *p.Decimal, Timing PaymentTiming) (*p.Decimal, error) {
+    var (
+        Decimals = CurrencyPrecision + InterestGuardDigits
+        Zero     = p.NFI(0)
+        One      = p.NFI(1)
+    )
+    if Rate.IsZero() == true {
+        if Payment.IsZero() == true {
+            return nil, fmt.Errorf("NPER: payment and rate are both zero")
+        }
+        Value := DIV(Decimals, ADD(Decimals, PresentValue, FutureValue), Payment)
+        return TruncToCurrency(Value.Neg(Value)), nil
+    }
+    Base := ADD(Decimals, One, Rate)
+    if DecimalLessThanOrEqual(Base, Zero) == true {
+        return nil, fmt.Errorf("NPER: the rate %s must be greater than -1", Rate.String())
+    }
+    //(1 + Rate) ** NPER = (X - FutureValue) / (X + PresentValue), X = Payment * (1 + Rate * Timing) / Rate
+    X := DIV(Decimals, MUL(Decimals, Payment, timingFactor(Decimals, Rate, Timing)), Rate)
+    Numerator, Denominator := SUB(Decimals, X, FutureValue), ADD(Decimals, X, PresentValue)
+    if Denominator.IsZero() == true || DecimalGreaterThan(DIV(Decimals, Numerator, Denominator), Zero) == false {
+        return nil, fmt.Errorf("NPER: the future value %s can not be reached", FutureValue.String())
+    }
+    return TruncToCurrency(DIV(Decimals, LN(Decimals, DIV(Decimals, Numerator, Denominator)), LN(Decimals, Base))), nil
+}
+
+// ================================================
+//
+// # Function 02.05 - RATE
+//
+// RATE returns the periodic rate at which Periods payments of Payment turn the PresentValue
+// into the FutureValue, truncated to "Decimals" decimals. The rate is searched
+// over RateSearchGrid and an error is returned when no root is bracketed.
+func RATE(Periods, Payment, PresentValue, FutureValue *p.Decimal, Timing PaymentTiming, Decimals uint32) (*p.Decimal, error) {
+    Working := Decimals + InterestGuardDigits
+    Balance := func(Rate *p.Decimal) (*p.Decimal, error) {
+        Growth, Annuity, err := tvmFactors(Working, Rate, Periods, Timing)
+        if err != nil {
+            return nil, err
+        }
+        return SUM(Working, MUL(Working, PresentValue, Growth), MUL(Working, Payment, Annuity), FutureValue), nil
+    }
+    Rate, err := solveOnGrid(Balance, Decimals)
+    if err != nil {
+        return nil, fmt.Errorf("RATE: %w", err)
+    }
+    return Rate, nil
+}
+
+// ================================================================================================
+//
+//	03 Cashflow Functions
+//
+// ================================================
+//
+// # Function 03.01 - NPV
+//
+// NPV returns the net present value of the Cashflows at the periodic Rate,
+// the first one being discounted one period, as spreadsheets do: sum(Cashflow[i] / (1 + Rate) ** (i + 1)).
+func NPV(Rate *p.Decimal, Cashflows []*p.Decimal) (*p.Decimal, error) {
+    Value, err := discountedSum(CurrencyPrecision+InterestGuardDigits, Rate, Cashflows, 1)
+    if err != nil {
+        return nil, fmt.Errorf("NPV: %w", err)
+    }
+    return TruncToCurrency(Value), nil
+}
+
+// ================================================
+//
+// # Function 03.02 - IRR
+//
+// IRR returns the periodic rate at which the net present value of the Cashflows is zero,
+// the first one not being discounted, truncated to "Decimals" decimals.
+// An error is returned when no root is bracketed over RateSearchGrid.
+func IRR(Cashflows []*p.Decimal, Decimals uint32) (*p.Decimal, error) {
+    if err := checkCashflowSigns(Cashflows); err != nil {
+        return nil, fmt.Errorf("IRR: %w", err)
+    }
+    Rate, err := solveOnGrid(func(Rate *p.Decimal) (*p.Decimal, error) {
+        return discountedSum(Decimals+InterestGuardDigits, Rate, Cashflows, 0)
+    }, Decimals)
+    if err != nil {
+        return nil, fmt.Errorf("IRR: %w", err)
+    }
+    return Rate, nil
+}
+
+// ================================================
+//
+// # Function 03.03 - XNPV
+//
+// XNPV returns the net present value of the Cashflows made at the given Dates, at the annual Rate,
+// discounted to the first date: sum(Cashflow[i] / (1 + Rate) ** ((Dates[i] - Dates[0]) / 365 days)).
+func XNPV(Rate *p.Decimal, Cashflows []*p.Decimal, Dates []time.Time) (*p.Decimal, error) {
+    Value, err := datedSum(CurrencyPrecision+InterestGuardDigits, Rate, Cashflows, Dates)
+    if err != nil {
+        return nil, fmt.Errorf("XNPV: %w", err)
+    }
+    return TruncToCurrency(Value), nil
+}
+
+// ================================================
+//
+// # Function 03.04 - XIRR
+//
+// XIRR returns the annual rate at which XNPV of the dated Cashflows is zero,
+// truncated to "Decimals" decimals.
+// An error is returned when no root is bracketed over RateSearchGrid.
+func XIRR(Cashflows []*p.Decimal, Dates []time.Time, Decimals uint32) (*p.Decimal, error) {
+    if err := checkCashflowSigns(Cashflows); err != nil {
+        return nil, fmt.Errorf("XIRR: %w", err)
+    }
+    Rate, err := solveOnGrid(func(Rate *p.Decimal) (*p.Decimal, error) {
+        return datedSum(Decimals+InterestGuardDigits, Rate, Cashflows, Dates)
+    }, Decimals)
+    if err != nil {
+        return nil, fmt.Errorf("XIRR: %w", err)
+    }
+    return Rate, nil
+}
+
+// ================================================================================================
+//
+//	04 Rate Solver
+//
+// ================================================
+//
+// # Function 04.01 - SolveRate
+//
+// SolveRate finds the rate between Low and High where Function is zero, by bisection,
+// and returns it truncated to "Decimals" decimals. An error is returned when
+// Function has the same sign at Low and at High, the root not being bracketed.
+func SolveRate(Function RateFunction, Low, High *p.Decimal, Decimals uint32) (*p.Decimal, error) {
+    Working := Decimals + InterestGuardDigits
+    FLow, err := Function(Low)
+    if err != nil {
+        return nil, err
+    }
+    FHigh, err := Function(High)
+    if err != nil {
+        return nil, err
+    }
+    switch {
+    case FLow.IsZero() == true:
+        return MUL(Decimals, Low, p.NFI(1)), nil
+    case FHigh.IsZero() == true:
+        return MUL(Decimals, High, p.NFI(1)), nil
+    case FLow.Negative == FHigh.Negative:
+        return nil, fmt.Errorf("no root is bracketed between %s and %s", Low.String(), High.String())
+    }
+    
+    //Bisecting until the bracket is 100 times narrower than the last requested decimal.
+    Width := DIV(Decimals+2, p.NFI(1), POWx(Decimals+3, p.NFI(10), p.NFI(int64(Decimals)+2)))
+    for DecimalGreaterThan(SUB(Working, High, Low), Width) == true {
+        Middle := DIV(Working, ADD(Working, Low, High), p.NFI(2))
+        FMiddle, err := Function(Middle)
+        if err != nil {
+            return nil, err
+        }
+        if FMiddle.IsZero() == true {
+            return MUL(Decimals, Middle, p.NFI(1)), nil
+        }
+        if FMiddle.Negative == FLow.Negative {
+            Low, FLow = Middle, FMiddle
+        } else {
+            High = Middle
+        }
+    }
+    return MUL(Decimals, DIV(Working, ADD(Working, Low, High), p.NFI(2)), p.NFI(1)), nil
+}
+
+// ================================================
+//
+// # Function 04.02 - BracketRate
+//
+// BracketRate returns the first two consecutive rates of RateSearchGrid
+// between which Function changes its sign. Rates at which Function fails or
+// returns a non-finite value are skipped, and no bracket spans them.
+func BracketRate(Function RateFunction) (*p.Decimal, *p.Decimal, error) {
+    var (
+        PreviousRate  *p.Decimal
+        PreviousValue *p.Decimal
+        LastError     error
+    )
+    for _, Text := range RateSearchGrid {
+        Rate := p.NFS(Text)
+        Value, err := Function(Rate)
+        if err != nil || Value.Form != p.Finite {
+            if err != nil {
+                LastError = err
+            }
+            PreviousRate, PreviousValue = nil, nil
+            continue
+        }
+        if Value.IsZero() == true {
+            return Rate, Rate, nil
+        }
+        if PreviousValue != nil && PreviousValue.Negative != Value.Negative {
+            return PreviousRate, Rate, nil
+        }
+        PreviousRate, PreviousValue = Rate, Value
+    }
+    if LastError != nil {
+        return nil, nil, fmt.Errorf("no root is bracketed between %s and %s: %w", RateSearchGrid[0], RateSearchGrid[len(RateSearchGrid)-1], LastError)
+    }
+    return nil, nil, fmt.Errorf("no root is bracketed between %s and %s", RateSearchGrid[0], RateSearchGrid[len(RateSearchGrid)-1])
+}
+
+// ================================================
+//
+// solveOnGrid brackets the root over RateSearchGrid, then solves it by bisection.
+func solveOnGrid(Function RateFunction, Decimals uint32) (*p.Decimal, error) {
+    Low, High, err := BracketRate(Function)
+    if err != nil {
+        return nil, err
+    }
+    return SolveRate(Function, Low, High, Decimals)
+}
+
+// ================================================
+//
+// tvmFactors returns the Growth (1 + Rate) ** Periods and the Annuity factor
+// (1 + Rate * Timing) * (Growth - 1) / Rate, which is Periods for a zero Rate.
+func tvmFactors(Decimals uint32, Rate, Periods *p.Decimal, Timing PaymentTiming) (*p.Decimal, *p.Decimal, error) {
+    if Rate.IsZero() == true {
+        return p.NFI(1), Periods, nil
+    }
+    Growth, err := compoundPeriods(Decimals, Rate, Periods)
+    if err != nil {
+        return nil, nil, err
+    }
+    Annuity := DIV(Decimals, MUL(Decimals, timingFactor(Decimals, Rate, Timing), SUB(Decimals, Growth, p.NFI(1))), Rate)
+    return Growth, Annuity, nil
+}
+
+// ================================================
+//
+// timingFactor returns 1 + Rate for payments due at the beginning of the periods, 1 otherwise.
+func timingFactor(Decimals uint32, Rate *p.Decimal, Timing PaymentTiming) *p.Decimal {
+    if Timing == PayAtBeginning {
+        return ADD(Decimals, p.NFI(1), Rate)
+    }
+    return p.NFI(1)
+}
+
+// ================================================
+//
+// compoundPeriods returns (1 + Rate) ** Periods, Periods being any decimal.
+func compoundPeriods(Decimals uint32, Rate, Periods *p.Decimal) (*p.Decimal, error) {
+    Base := ADD(Decimals, p.NFI(1), Rate)
+    if DecimalLessThanOrEqual(Base, p.NFI(0)) == true {
+        return nil, fmt.Errorf("the rate %s must be greater than -1", Rate.String())
+    }
+    Whole := MUL(0, Periods, p.NFI(1))
+    if DecimalEqual(Whole, Periods) == true && Periods.Negative == false {
+        return powInt(Decimals, Base, p.INT64(Whole)), nil
+    }
+    return EXP(Decimals, MUL(Decimals, Periods, LN(Decimals, Base))), nil
+}
+
+// ================================================
+//
+// discountedSum returns sum(Cashflow[i] / (1 + Rate) ** (i + Offset)).
+func discountedSum(Decimals uint32, Rate *p.Decimal, Cashflows []*p.Decimal, Offset int64) (*p.Decimal, error) {
+    Sum := p.NFI(0)
+    for i, Cashflow := range Cashflows {
+        Growth, err := compoundPeriods(Decimals, Rate, p.NFI(int64(i)+Offset))
+        if err != nil {
+            return nil, err
+        }
+        Value, err := discount(Decimals, Cashflow, Growth)
+        if err != nil {
+            return nil, err
+        }
+        Sum = ADD(Decimals, Sum, Value)
+    }
+    return Sum, nil
+}
+
+// ================================================
+//
+// datedSum returns sum(Cashflow[i] / (1 + Rate) ** ((Dates[i] - Dates[0]) / 365 days)).
+func datedSum(Decimals uint32, Rate *p.Decimal, Cashflows []*p.Decimal, Dates []time.Time) (*p.Decimal, error) {
+    if len(Cashflows) != len(Dates) {
+        return nil, fmt.Errorf("%d cashflows have %d dates", len(Cashflows), len(Dates))
+    }
+    Sum := p.NFI(0)
+    for i, Cashflow := range Cashflows {
+        Days := p.NFI(int64(Dates[i].Sub(Dates[0]) / (24 * time.Hour)))
+        Growth, err := compoundPeriods(Decimals, Rate, DIV(Decimals, Days, p.NFI(365)))
+        if err != nil {
+            return nil, err
+        }
+        Value, err := discount(Decimals, Cashflow, Growth)
+        if err != nil {
+            return nil, err
+        }
+        Sum = ADD(Decimals, Sum, Value)
+    }
+    return Sum, nil
+}
+
+// ================================================
+//
+// discount returns Cashflow / Growth, or an error when the Growth factor
+// vanished below the working precision or is not finite.
+func discount(Decimals uint32, Cashflow, Growth *p.Decimal) (*p.Decimal, error) {
+    if Growth.Form != p.Finite || Growth.IsZero() == true {
+        return nil, fmt.Errorf("the discount factor %s is not usable at %d decimals", Growth.String(), Decimals)
+    }
+    return DIV(Decimals, Cashflow, Growth), nil
+}
+
+// ================================================
+//
+// checkCashflowSigns returns an error unless the Cashflows hold both a positive and a negative value.
+func checkCashflowSigns(Cashflows []*p.Decimal) error {
+    var Positive, Negative bool
+    for _, Cashflow := range Cashflows {
+        if Cashflow.IsZero() == false {
+            Positive = Positive || Cashflow.Negative == false
+            Negative = Negative || Cashflow.Negative == true
+        }
+    }
+    if Positive == false || Negative == false {
+        return fmt.Errorf("the cashflows need both a positive and a negative value")
+    }
+    return nil
+}
@@ -0,0 +1,167 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "strconv"
+    "strings"
+)
+
+//
+//	        TokenProfiles.go			Decimal Layout of CryptoCurrency Tokens
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 Token Profile Type
+//			01  - TokenProfile			Name, ticker, decimals, atomic unit and display layout of a token
+//			02  - CryptoPlasmProfile		The CryptoPlasm (Koson) profile, 18 decimals counted in AttoPlasms
+//		02 Token Profile Methods
+//			01  - TokenProfile.AtomicUnits		Returns the number of atomic units in one token
+//			02  - TokenProfile.Truncate		Truncates an amount to the token decimals
+//			03  - TokenProfile.Convert2AU		Converts a token amount into atomic units
+//			04  - TokenProfile.AtomicUnits2String	Converts atomic units into a slice of digit strings
+//			05  - TokenProfile.Format		Formats a token amount using the profile schema
+//			06  - TokenProfile.Parse		Parses a string produced by TokenProfile.Format
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 Token Profile Type
+//
+// ================================================
+//
+// # Type 01.01 - TokenProfile
+//
+// TokenProfile describes the decimal layout of a token, so that the currency functions
+// can be used for tokens having other than 18 decimals. For a 6 decimals token
+// counted in "micro" units, displayed with the Kosonic layout:
+//
+//	TokenProfile{Name: "Micro", Ticker: "MIC", Decimals: 6, AtomicUnitName: "MicroUnit", Schema: KosonicSchema}
+//
+// formats 1234.567891 as 1.234,[567|891]. The Schema Brackets set the grouping of the decimals.
+type TokenProfile struct {
+    Name           string
+    Ticker         string
+    Decimals       uint32
+    AtomicUnitName string
+    Schema         FormatSchema
+}
+
+// ================================================
+//
+// # Variable 01.02 - CryptoPlasmProfile
+//
+// CryptoPlasmProfile is the profile used by Convert2AU, AttoPlasm2String,
+// TruncToCurrency and KosonicDecimalConversion.
+var CryptoPlasmProfile = TokenProfile{
+    Name:           "CryptoPlasm",
+    Ticker:         "CP",
+    Decimals:       CurrencyPrecision,
+    AtomicUnitName: "AttoPlasm",
+    Schema:         KosonicSchema,
+}
+
+// ================================================================================================
+//
+//	02 Token Profile Methods
+//
+// ================================================
+//
+// # Function 02.01 - TokenProfile.AtomicUnits
+//
+// AtomicUnits returns the number of atomic units making one token, 10^Decimals.
+// For the CryptoPlasmProfile it equals AuPerUnit.
+func (tp TokenProfile) AtomicUnits() *p.Decimal {
+    return p.NFS("1" + strings.Repeat("0", int(tp.Decimals)))
+}
+
+// ================================================
+//
+// # Function 02.02 - TokenProfile.Truncate
+//
+// Truncate truncates the amount to the token decimals.
+// It is Context Precision Independent
+func (tp TokenProfile) Truncate(Amount2BeTruncated *p.Decimal) *p.Decimal {
+    return TruncateCustom(Amount2BeTruncated, tp.Decimals)
+}
+
+// ================================================
+//
+// # Function 02.03 - TokenProfile.Convert2AU
+//
+// Convert2AU converts a token amount into atomic units, truncating the
+// digits beyond the token decimals.
+func (tp TokenProfile) Convert2AU(Amount *p.Decimal) *p.Decimal {
+    tAmount := tp.Truncate(Amount)
+    NumberDigits := Count4Coma(Amount)
+    IP := uint32(NumberDigits) + tp.Decimals
+    AU := MULx(IP, tAmount, tp.AtomicUnits())
+    
+    return AU
+}
+
+// ================================================
+//
+// # Function 02.04 - TokenProfile.AtomicUnits2String
+//
+// AtomicUnits2String converts an amount of atomic units into a slice of digit strings,
+// padded with leading zeros to at least Decimals + 1 digits, so that the last
+// Decimals strings are always the decimals of the token amount.
+// For a 6 decimals token, 1234 atomic units give ["0" "0" "0" "1" "2" "3" "4"]
+func (tp TokenProfile) AtomicUnits2String(Number *p.Decimal) []string {
+    SliceStr := atomicDigits(Number)
+    for len(SliceStr) < int(tp.Decimals)+1 {
+        SliceStr = append([]string{"0"}, SliceStr...)
+    }
+    return SliceStr
+}
+
+// ================================================
+//
+// # Function 02.05 - TokenProfile.Format
+//
+// Format converts the token amount into a display string using the profile Schema,
+// displaying all the token decimals.
+func (tp TokenProfile) Format(Amount *p.Decimal) string {
+    return FormatDecimal(Amount, tp.Decimals, tp.Schema)
+}
+
+// ================================================
+//
+// # Function 02.06 - TokenProfile.Parse
+//
+// Parse converts a string produced by Format back into the token amount.
+func (tp TokenProfile) Parse(Text string) (*p.Decimal, error) {
+    return ParseFormatted(Text, tp.Decimals, tp.Schema)
+}
+
+// ================================================
+//
+// atomicDigits splits an integer number of atomic units into its decimal digits,
+// the most significant one first.
+func atomicDigits(Number *p.Decimal) []string {
+    var SliceStr []string
+    Ten := p.NFI(10)
+    AuDigits := Number.NumDigits()
+    Exp := AuDigits - 1
+    IP := uint32(AuDigits)
+    ToSequence := Number
+    for i := Exp; i >= 0; i-- {
+        idec := p.NFI(i)
+        Power := POWx(IP, Ten, idec)
+        Division := DIVx(IP, ToSequence, Power)
+        DigitIs := TruncateCustom(Division, 0)
+        DI := p.INT64(DigitIs)
+        DigitIsString := strconv.Itoa(int(DI))
+        SliceStr = append(SliceStr, DigitIsString)
+        
+        Rest := SUBx(IP, Division, DigitIs)
+        SmallAU := MULx(IP, Rest, Power)
+        ToSequence = SmallAU
+    }
+    return SliceStr
+}
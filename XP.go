@@ -0,0 +1,269 @@
+package SuperMath
+
+import (
+    p "Firefly-APD"
+    "fmt"
+)
+
+//
+//	        XP.go					XP (Seed) Points and Leveling Curves
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//		Function List:
+//
+//		01 XP Types
+//			01  - XP				Non-negative amount of XP points, with XPPrecision decimals
+//			02  - CurveKind				Shape of a leveling curve
+//			03  - LevelCurve			Leveling curve with decimal parameters
+//		02 XP Functions
+//			01  - NewXP				Creates XP from a decimal, truncated with TruncSeed
+//			02  - XP.Add				Accumulates XP points
+//			03  - XP.Points				Returns the XP points as a decimal
+//			04  - XP.String				Formats the XP points using the Kosonic separators
+//			05  - ParseXP				Parses a string produced by XP.String
+//		03 Leveling Functions
+//			01  - LevelCurve.XPForLevel		Returns the XP needed to reach a level
+//			02  - LevelCurve.LevelFromXP		Returns the level reached with an amount of XP
+//			03  - LevelCurve.Progress		Returns the progress towards the next level in percent
+//
+// ================================================================================================
+// ************************************************************************************************
+// ================================================================================================
+//
+//	01 XP Types
+//		XP points have XPPrecision (8) decimals. Levels start at 0, reached with no XP.
+//
+// ================================================
+//
+// MaxLevel is the highest level the leveling functions search for.
+const MaxLevel = int64(1) << 40
+
+// xpWorkingDecimals are the decimals used while computing leveling curves.
+const xpWorkingDecimals = XPPrecision + 10
+
+// ================================================
+//
+// # Type 01.01 - XP
+//
+// XP is a non-negative amount of XP points, having XPPrecision decimals.
+// The zero value holds zero points.
+type XP struct {
+    points *p.Decimal
+}
+
+// ================================================
+//
+// # Type 01.02 - CurveKind
+//
+// CurveKind sets the shape of a leveling curve.
+type CurveKind int
+
+const (
+    CurveLinear      CurveKind = iota // Base * Level
+    CurvePolynomial                   // Base * Level ** Exponent
+    CurveExponential                  // Base * (Factor ** Level - 1) / (Factor - 1), each level costing Factor times the previous one
+)
+
+// ================================================
+//
+// # Type 01.03 - LevelCurve
+//
+// LevelCurve gives the total XP needed to reach each level. Base is the XP needed
+// for level 1; Exponent is used by polynomial curves and Factor by exponential ones.
+type LevelCurve struct {
+    Kind     CurveKind
+    Base     *p.Decimal
+    Exponent *p.Decimal
+    Factor   *p.Decimal
+}
+
+// ================================================================================================
+//
+//	02 XP Functions
+//
+// ================================================
+//
+// # Function 02.01 - NewXP
+//
+// NewXP creates XP from the decimal, truncated to XPPrecision like TruncSeed does.
+func NewXP(Points *p.Decimal) (XP, error) {
+    if DecimalLessThan(Points, p.NFI(0)) == true {
+        return XP{}, fmt.Errorf("NewXP: negative XP %s", Points.String())
+    }
+    return XP{points: MUL(XPPrecision, Points, p.NFI(1))}, nil
+}
+
+// ================================================
+//
+// # Function 02.02 - XP.Add
+//
+// Add returns the XP increased by Gained points, truncated to XPPrecision.
+func (x XP) Add(Gained *p.Decimal) (XP, error) {
+    if DecimalLessThan(Gained, p.NFI(0)) == true {
+        return XP{}, fmt.Errorf("XP.Add: negative XP %s", Gained.String())
+    }
+    return XP{points: ADD(XPPrecision, x.Points(), MUL(XPPrecision, Gained, p.NFI(1)))}, nil
+}
+
+// ================================================
+//
+// # Function 02.03 - XP.Points
+//
+// Points returns the XP points as a decimal.
+func (x XP) Points() *p.Decimal {
+    if x.points == nil {
+        return p.NFI(0)
+    }
+    return new(p.Decimal).Set(x.points)
+}
+
+// ================================================
+//
+// # Function 02.04 - XP.String
+//
+// String formats the XP points with XPPrecision decimals using the KosonicSchema separators,
+// for instance 1234567.12345678 is printed as 1.234.567,[123|456|78]
+func (x XP) String() string {
+    return FormatDecimal(x.Points(), XPPrecision, KosonicSchema)
+}
+
+// ================================================
+//
+// # Function 02.05 - ParseXP
+//
+// ParseXP converts a string produced by XP.String back into XP.
+func ParseXP(Text string) (XP, error) {
+    Points, err := ParseFormatted(Text, XPPrecision, KosonicSchema)
+    if err != nil {
+        return XP{}, fmt.Errorf("ParseXP: %w", err)
+    }
+    return NewXP(Points)
+}
+
+// ================================================================================================
+//
+//	03 Leveling Functions
+//
+// ================================================
+//
+// # Function 03.01 - LevelCurve.XPForLevel
+//
+// XPForLevel returns the total XP needed to reach the Level, truncated to XPPrecision.
+// With a linear curve of Base 100, level 3 needs 300 XP; with a polynomial curve of Base 100
+// and Exponent 2 it needs 900 XP; with an exponential curve of Base 100 and Factor 2 it needs 700 XP.
+func (c LevelCurve) XPForLevel(Level int64) (*p.Decimal, error) {
+    var (
+        Result *p.Decimal
+        Zero   = p.NFI(0)
+        L      = p.NFI(Level)
+    )
+    if Level < 0 {
+        return nil, fmt.Errorf("LevelCurve.XPForLevel: negative level %d", Level)
+    }
+    if c.Base == nil || DecimalLessThanOrEqual(c.Base, Zero) == true {
+        return nil, fmt.Errorf("LevelCurve.XPForLevel: the curve Base must be positive")
+    }
+    if Level == 0 {
+        return Zero, nil
+    }
+    switch c.Kind {
+    case CurveLinear:
+        Result = MUL(xpWorkingDecimals, c.Base, L)
+    case CurvePolynomial:
+        if c.Exponent == nil || DecimalLessThanOrEqual(c.Exponent, Zero) == true {
+            return nil, fmt.Errorf("LevelCurve.XPForLevel: the curve Exponent must be positive")
+        }
+        if Whole := MUL(0, c.Exponent, p.NFI(1)); DecimalEqual(Whole, c.Exponent) == true {
+            Result = MUL(xpWorkingDecimals, c.Base, powInt(xpWorkingDecimals, L, p.INT64(Whole)))
+        } else {
+            Result = MUL(xpWorkingDecimals, c.Base, EXP(xpWorkingDecimals, MUL(xpWorkingDecimals, c.Exponent, LN(xpWorkingDecimals, L))))
+        }
+    case CurveExponential:
+        if c.Factor == nil || DecimalLessThanOrEqual(c.Factor, p.NFI(1)) == true {
+            return nil, fmt.Errorf("LevelCurve.XPForLevel: the curve Factor must be greater than 1")
+        }
+        Growth := SUB(xpWorkingDecimals, powInt(xpWorkingDecimals, c.Factor, Level), p.NFI(1))
+        Result = DIV(xpWorkingDecimals, MUL(xpWorkingDecimals, c.Base, Growth), SUB(xpWorkingDecimals, c.Factor, p.NFI(1)))
+    default:
+        return nil, fmt.Errorf("LevelCurve.XPForLevel: unknown curve kind %d", c.Kind)
+    }
+    return MUL(XPPrecision, Result, p.NFI(1)), nil
+}
+
+// ================================================
+//
+// # Function 03.02 - LevelCurve.LevelFromXP
+//
+// LevelFromXP returns the highest level whose XPForLevel does not exceed the XP, up to MaxLevel.
+func (c LevelCurve) LevelFromXP(x XP) (int64, error) {
+    Points := x.Points()
+    //Reaches reports whether the XP is enough for the Level.
+    Reaches := func(Level int64) (bool, error) {
+        Needed, err := c.XPForLevel(Level)
+        if err != nil {
+            return false, err
+        }
+        return DecimalGreaterThanOrEqual(Points, Needed), nil
+    }
+    
+    //Doubling the upper bound until the XP is no longer enough, then bisecting.
+    Low, High := int64(0), int64(1)
+    for High <= MaxLevel {
+        Enough, err := Reaches(High)
+        if err != nil {
+            return 0, fmt.Errorf("LevelCurve.LevelFromXP: %w", err)
+        }
+        if Enough == false {
+            break
+        }
+        Low, High = High, High*2
+    }
+    if High > MaxLevel {
+        return MaxLevel, nil
+    }
+    for High-Low > 1 {
+        Middle := Low + (High-Low)/2
+        Enough, err := Reaches(Middle)
+        if err != nil {
+            return 0, fmt.Errorf("LevelCurve.LevelFromXP: %w", err)
+        }
+        if Enough == true {
+            Low = Middle
+        } else {
+            High = Middle
+        }
+    }
+    return Low, nil
+}
+
+// ================================================
+//
+// # Function 03.03 - LevelCurve.Progress
+//
+// Progress returns the current level and the progress towards the next one,
+// in percent, truncated with TruncPercent. With a linear curve of Base 100,
+// 250 XP is level 2 with 50 percent progress.
+func (c LevelCurve) Progress(x XP) (int64, *p.Decimal, error) {
+    Level, err := c.LevelFromXP(x)
+    if err != nil {
+        return 0, nil, fmt.Errorf("LevelCurve.Progress: %w", err)
+    }
+    Current, err := c.XPForLevel(Level)
+    if err != nil {
+        return 0, nil, fmt.Errorf("LevelCurve.Progress: %w", err)
+    }
+    Next, err := c.XPForLevel(Level + 1)
+    if err != nil {
+        return 0, nil, fmt.Errorf("LevelCurve.Progress: %w", err)
+    }
+    Gained := SUB(XPPrecision, x.Points(), Current)
+    Span := SUB(XPPrecision, Next, Current)
+    if Span.IsZero() == true {
+        return Level, p.NFI(0), nil
+    }
+    Percent := DIV(PromillePrecision, MUL(XPPrecision, Gained, p.NFI(100)), Span)
+    return Level, TruncPercent(Percent), nil
+}